@@ -10,6 +10,11 @@ type Clock interface {
 	Now() time.Time
 	NewTicker(d time.Duration) *Ticker
 	NewTimer(d time.Duration) *Timer
+	Sleep(d time.Duration)
+	After(d time.Duration) <-chan time.Time
+	AfterFunc(d time.Duration, f func()) *Timer
+	Since(t time.Time) time.Duration
+	Until(t time.Time) time.Duration
 }
 
 // Timerable is interface for [time.Timer].
@@ -29,6 +34,7 @@ type Timer struct {
 	// The real Timer implementation
 	Timerable
 	// The channel on which the timer result are delivered.
+	// It is nil for Timer created by AfterFunc.
 	C <-chan time.Time
 }
 
@@ -68,3 +74,23 @@ func (c *clock) NewTicker(d time.Duration) *Ticker {
 		C:          t.C,
 	}
 }
+
+func (c *clock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func (c *clock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (c *clock) AfterFunc(d time.Duration, f func()) *Timer {
+	return &Timer{Timerable: time.AfterFunc(d, f)}
+}
+
+func (c *clock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (c *clock) Until(t time.Time) time.Duration {
+	return time.Until(t)
+}
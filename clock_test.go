@@ -68,4 +68,41 @@ var _ = Describe("Clock", func() {
 			Expect(ct3).To(BeTemporally("~", ct2.Add(d2), th), "ct3 - ct2")
 		})
 	})
+
+	Describe("Sleep", func() {
+		It("pauses at least the duration", func() {
+			t1 := time.Now()
+			c.Sleep(20 * ms)
+			Expect(time.Since(t1)).To(BeNumerically(">=", 20*ms))
+		})
+	})
+
+	Describe("After", func() {
+		It("sends the time after the duration", func() {
+			t1 := time.Now()
+			ch := c.After(20 * ms)
+			Expect(<-ch).To(BeTemporally(">=", t1.Add(20*ms)))
+		})
+	})
+
+	Describe("AfterFunc", func() {
+		It("calls the func after the duration", func() {
+			done := make(chan time.Time, 1)
+			t1 := time.Now()
+			t := c.AfterFunc(20*ms, func() { done <- time.Now() })
+			Expect(t.C).To(BeNil())
+			Expect(<-done).To(BeTemporally(">=", t1.Add(20*ms)))
+			Expect(t.Stop()).To(BeFalse())
+		})
+	})
+
+	Describe("Since and Until", func() {
+		It("return the duration from now", func() {
+			t1 := time.Now()
+			Expect(c.Since(t1.Add(-time.Second))).
+				To(BeNumerically(">=", time.Second))
+			Expect(c.Until(t1.Add(time.Second))).
+				To(BeNumerically("<=", time.Second))
+		})
+	})
 })
@@ -0,0 +1,282 @@
+// Command clockviz prints an ASCII timeline of a clock trace, either the
+// Chrome trace written by clock.Mock WriteTrace() or the recording written
+// by clock.RecordingClock, e.g.
+//
+//	clockviz -width 60 /tmp/clock-trace-123.json
+//
+// Every Timer/Ticker has its own lane, "clock" lane is for Now, Since and
+// Until calls. The markers are A for New, R for Reset, S for Stop, F for
+// fire, N for Now and "-" while it's active.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bangzek/clock"
+)
+
+func main() {
+	width := flag.Int("width", 72, "timeline width in columns")
+	list := flag.Bool("list", false, "list every marker with its time")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(),
+			"usage: %s [flags] [trace-file]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var r io.Reader = os.Stdin
+	if flag.NArg() > 0 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "clockviz:", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		r = f
+	}
+	tl, err := parse(r)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "clockviz:", err)
+		os.Exit(1)
+	}
+	tl.render(os.Stdout, max(*width, 10), *list)
+}
+
+// ===========================================================================
+
+// Marker priority when they're in the same column.
+const markers = "-NARSF"
+
+type mark struct {
+	at time.Duration
+	c  byte
+}
+
+type span struct {
+	from, to time.Duration
+}
+
+type lane struct {
+	name  string
+	marks []mark
+	spans []span
+}
+
+type timeline struct {
+	lanes []*lane
+	end   time.Duration
+}
+
+func (tl *timeline) lane(name string) *lane {
+	for _, l := range tl.lanes {
+		if l.name == name {
+			return l
+		}
+	}
+	l := &lane{name: name}
+	tl.lanes = append(tl.lanes, l)
+	return l
+}
+
+func (tl *timeline) mark(l *lane, at time.Duration, c byte) {
+	l.marks = append(l.marks, mark{at, c})
+	tl.end = max(tl.end, at)
+}
+
+func (tl *timeline) span(l *lane, from, to time.Duration) {
+	l.spans = append(l.spans, span{from, to})
+	tl.end = max(tl.end, to)
+}
+
+// ===========================================================================
+
+// parse reads Chrome trace or RecordingClock recording.
+func parse(r io.Reader) (*timeline, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Contains(b, []byte(`"traceEvents"`)) {
+		return parseTrace(b)
+	}
+	return parseRecording(b)
+}
+
+func parseTrace(b []byte) (*timeline, error) {
+	var tr struct {
+		TraceEvents []struct {
+			Name string
+			Ph   string
+			Ts   float64
+			Dur  float64
+			Tid  int
+			Args map[string]any
+		}
+	}
+	if err := json.Unmarshal(b, &tr); err != nil {
+		return nil, err
+	}
+	us := func(f float64) time.Duration {
+		return time.Duration(f * float64(time.Microsecond))
+	}
+
+	tl := new(timeline)
+	names := make(map[int]string)
+	for _, e := range tr.TraceEvents {
+		if e.Ph == "M" && e.Name == "thread_name" {
+			names[e.Tid], _ = e.Args["name"].(string)
+			tl.lane(names[e.Tid])
+		}
+	}
+	fired := make(map[*lane]time.Duration)
+	for _, e := range tr.TraceEvents {
+		l := tl.lane(names[e.Tid])
+		switch e.Ph {
+		case "i":
+			tl.mark(l, us(e.Ts), 'N')
+		case "X":
+			from, to := us(e.Ts), us(e.Ts+e.Dur)
+			switch {
+			case strings.Contains(e.Name, ".reset "):
+				tl.mark(l, from, 'R')
+			case fired[l] != from || len(l.spans) == 0:
+				// not the next Ticker span after its fire
+				tl.mark(l, from, 'A')
+			}
+			tl.span(l, from, to)
+			switch e.Args["end"] {
+			case "fire":
+				tl.mark(l, to, 'F')
+				fired[l] = to
+			case "stop":
+				tl.mark(l, to, 'S')
+			}
+		}
+	}
+	return tl, nil
+}
+
+func parseRecording(b []byte) (*timeline, error) {
+	tl := new(timeline)
+	clk := tl.lane("clock")
+	var start time.Time
+	armed := make(map[*lane]time.Duration)
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	sc.Buffer(nil, 1<<20)
+	for line := 1; sc.Scan(); line++ {
+		var e clock.Event
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if line == 1 {
+			start = e.Time
+		}
+		at := e.Time.Sub(start)
+		if e.Kind == clock.KindNow {
+			tl.mark(clk, at, 'N')
+			continue
+		}
+
+		name := e.Name
+		if e.Label != "" {
+			name = e.Label
+		}
+		l := tl.lane(name + "-" + strconv.Itoa(e.No))
+		if from, ok := armed[l]; ok && e.Kind != clock.KindNew {
+			tl.span(l, from, at)
+			delete(armed, l)
+		}
+		switch e.Kind {
+		case clock.KindNew:
+			tl.mark(l, at, 'A')
+			armed[l] = at
+		case clock.KindReset:
+			tl.mark(l, at, 'R')
+			armed[l] = at
+		case clock.KindStop:
+			tl.mark(l, at, 'S')
+		case clock.KindFire:
+			tl.mark(l, at, 'F')
+			if e.Name == "ticker" {
+				armed[l] = at
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	for l, from := range armed {
+		tl.span(l, from, tl.end)
+	}
+	if len(clk.marks) == 0 {
+		tl.lanes = tl.lanes[1:]
+	}
+	return tl, nil
+}
+
+// ===========================================================================
+
+func (tl *timeline) render(w io.Writer, width int, list bool) {
+	nw := len("clock")
+	for _, l := range tl.lanes {
+		nw = max(nw, len(l.name))
+	}
+	col := func(at time.Duration) int {
+		if tl.end <= 0 {
+			return 0
+		}
+		return min(int(int64(at)*int64(width-1)/int64(tl.end)), width-1)
+	}
+
+	end := tl.end.String()
+	fmt.Fprintf(w, "%-*s  0s%*s\n", nw, "", width-2, end)
+	for _, l := range tl.lanes {
+		row := bytes.Repeat([]byte{' '}, width)
+		for _, s := range l.spans {
+			for i := col(s.from); i <= col(s.to); i++ {
+				row[i] = '-'
+			}
+		}
+		for _, m := range l.marks {
+			i := col(m.at)
+			if strings.IndexByte(markers, m.c) >=
+				strings.IndexByte(markers, row[i]) {
+				row[i] = m.c
+			}
+		}
+		fmt.Fprintf(w, "%-*s |%s|\n", nw, l.name, row)
+	}
+	fmt.Fprintf(w, "A new  R reset  S stop  F fire  N now, 1 column = %s\n",
+		(tl.end / time.Duration(width-1)).Round(time.Microsecond))
+
+	if list {
+		type entry struct {
+			mark
+			name string
+		}
+		var all []entry
+		for _, l := range tl.lanes {
+			for _, m := range l.marks {
+				all = append(all, entry{m, l.name})
+			}
+		}
+		slices.SortStableFunc(all, func(a, b entry) int {
+			return cmp.Compare(a.at, b.at)
+		})
+		for _, e := range all {
+			fmt.Fprintf(w, "%14s %c %s\n", "+"+e.at.String(), e.c, e.name)
+		}
+	}
+}
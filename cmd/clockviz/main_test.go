@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/bangzek/clock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("clockviz", func() {
+	tm := time.Date(2021, time.February, 1, 23, 24, 25, 0, time.UTC)
+
+	It("renders Mock trace", func() {
+		c := &clock.Mock{Virtual: true, Default: clock.Script{Now: 10 * ms}}
+		c.Start(tm)
+		c.Now()
+		t := c.NewTimer(100 * ms)
+		tk := c.NewTicker(30 * ms)
+		for range 5 {
+			c.Advance(20 * ms)
+		}
+		t.Reset(50 * ms)
+		t.Stop()
+		c.Advance(20 * ms)
+		tk.Stop()
+		c.Stop()
+
+		var in, out bytes.Buffer
+		Expect(c.WriteTrace(&in)).To(Succeed())
+		tl, err := parse(&in)
+		Expect(err).NotTo(HaveOccurred())
+		tl.render(&out, 17, true)
+		Expect(strings.Split(out.String(), "\n")).To(Equal([]string{
+			"          0s          160ms",
+			"clock    | N               |",
+			"timer-1  |  A---------F S  |",
+			"ticker-1 |   A--F--F--F--FS|",
+			"A new  R reset  S stop  F fire  N now, 1 column = 10ms",
+			"         +10ms N clock",
+			"         +20ms A timer-1",
+			"         +30ms A ticker-1",
+			"         +60ms F ticker-1",
+			"         +90ms F ticker-1",
+			"        +120ms F timer-1",
+			"        +120ms F ticker-1",
+			"        +140ms R timer-1",
+			"        +140ms S timer-1",
+			"        +150ms F ticker-1",
+			"        +160ms S ticker-1",
+			"",
+		}))
+	})
+
+	It("renders RecordingClock recording", func() {
+		var in, out bytes.Buffer
+		rc := clock.NewRecordingClock(clock.New(), &in)
+		rc.Now()
+		t := rc.NewTimer(ms)
+		<-t.C
+		clock.Labeled(rc, "retry").NewTimer(time.Hour).Stop()
+
+		tl, err := parse(&in)
+		Expect(err).NotTo(HaveOccurred())
+		tl.render(&out, 20, false)
+		lines := strings.Split(out.String(), "\n")
+		Expect(lines).To(HaveLen(6))
+		Expect(lines[1]).To(Equal("clock   |N                   |"))
+		Expect(lines[2]).To(MatchRegexp(`^timer-1 \| *A-+F +\|$`))
+		Expect(lines[3]).To(MatchRegexp(`^retry-1 \| +A?-*S\|$`))
+	})
+})
@@ -0,0 +1,180 @@
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type clockKey struct{}
+
+// WithClock returns a copy of ctx carrying Clock c, see FromContext().
+func WithClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, c)
+}
+
+// FromContext returns the Clock carried by ctx, or the real-time Clock when
+// there is none.
+func FromContext(ctx context.Context) Clock {
+	if c, ok := ctx.Value(clockKey{}).(Clock); ok {
+		return c
+	}
+	return New()
+}
+
+// Now returns the current time of the Clock carried by ctx.
+func Now(ctx context.Context) time.Time {
+	return FromContext(ctx).Now()
+}
+
+// Since returns the time elapsed since t by the Clock carried by ctx.
+func Since(ctx context.Context, t time.Time) time.Duration {
+	return FromContext(ctx).Since(t)
+}
+
+// Until returns the duration until t by the Clock carried by ctx.
+func Until(ctx context.Context, t time.Time) time.Duration {
+	return FromContext(ctx).Until(t)
+}
+
+// NewTimer returns a new Timer of the Clock carried by ctx.
+func NewTimer(ctx context.Context, d time.Duration) *Timer {
+	return FromContext(ctx).NewTimer(d)
+}
+
+// NewTicker returns a new Ticker of the Clock carried by ctx.
+func NewTicker(ctx context.Context, d time.Duration) *Ticker {
+	return FromContext(ctx).NewTicker(d)
+}
+
+// After waits for the duration on the Clock carried by ctx.
+func After(ctx context.Context, d time.Duration) <-chan time.Time {
+	return FromContext(ctx).After(d)
+}
+
+// AfterFunc calls f after the duration on the Clock carried by ctx.
+func AfterFunc(ctx context.Context, d time.Duration, f func()) *Timer {
+	return FromContext(ctx).AfterFunc(d, f)
+}
+
+// ===========================================================================
+
+// WithDeadline is like [context.WithDeadline] but the deadline is governed by
+// Clock c, so it can be tested by Mock.
+func WithDeadline(parent context.Context, c Clock, d time.Time) (
+	context.Context, context.CancelFunc,
+) {
+	return WithDeadlineCause(parent, c, d, nil)
+}
+
+// WithDeadlineCause is like [context.WithDeadlineCause] but the deadline is
+// governed by Clock c.
+func WithDeadlineCause(parent context.Context, c Clock, d time.Time,
+	cause error,
+) (context.Context, context.CancelFunc) {
+	return withDeadline(parent, c, d, c.Until(d), cause)
+}
+
+// WithTimeout is like [context.WithTimeout] but the timeout is governed by
+// Clock c.
+func WithTimeout(parent context.Context, c Clock, timeout time.Duration) (
+	context.Context, context.CancelFunc,
+) {
+	return WithTimeoutCause(parent, c, timeout, nil)
+}
+
+// WithTimeoutCause is like [context.WithTimeoutCause] but the timeout is
+// governed by Clock c.
+func WithTimeoutCause(parent context.Context, c Clock,
+	timeout time.Duration, cause error,
+) (context.Context, context.CancelFunc) {
+	return withDeadline(parent, c, c.Now().Add(timeout), timeout, cause)
+}
+
+func withDeadline(parent context.Context, c Clock, d time.Time,
+	left time.Duration, cause error,
+) (context.Context, context.CancelFunc) {
+	if cur, ok := parent.Deadline(); ok && cur.Before(d) {
+		// The parent's deadline is earlier, so it governs, just like
+		// context.WithDeadline.
+		return context.WithCancel(parent)
+	}
+	inner, cancel := context.WithCancelCause(parent)
+	ctx := &deadlineCtx{
+		Context:  inner,
+		deadline: d,
+		done:     make(chan struct{}),
+	}
+	if cause == nil {
+		cause = context.DeadlineExceeded
+	}
+	ctx.finish = sync.OnceFunc(func() {
+		ctx.lock.Lock()
+		if ctx.timer != nil && !ctx.expired {
+			ctx.timer.Stop()
+		}
+		ctx.lock.Unlock()
+		close(ctx.done)
+	})
+	expire := func() {
+		ctx.lock.Lock()
+		ctx.expired = inner.Err() == nil
+		ctx.lock.Unlock()
+		cancel(cause)
+		ctx.finish()
+	}
+
+	if left <= 0 {
+		expire()
+		return ctx, func() {}
+	}
+	ctx.lock.Lock()
+	ctx.timer = c.AfterFunc(left, expire)
+	ctx.lock.Unlock()
+	context.AfterFunc(inner, ctx.finish)
+	return ctx, func() {
+		cancel(context.Canceled)
+		ctx.finish()
+	}
+}
+
+// ===========================================================================
+
+// deadlineCtx has its own done channel, so the children are canceled with
+// its Err() instead of the inner one which is always context.Canceled.
+type deadlineCtx struct {
+	context.Context
+	deadline time.Time
+	done     chan struct{}
+	finish   func()
+	timer    *Timer
+	expired  bool
+	lock     sync.Mutex
+}
+
+func (c *deadlineCtx) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
+
+func (c *deadlineCtx) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *deadlineCtx) Err() error {
+	select {
+	case <-c.done:
+	default:
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.expired {
+		return context.DeadlineExceeded
+	}
+	return c.Context.Err()
+}
+
+func (c *deadlineCtx) String() string {
+	return "clock.WithDeadline(" + c.deadline.String() + ")"
+}
@@ -0,0 +1,142 @@
+package clock_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/bangzek/clock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Context", func() {
+	var c *Mock
+	tm := time.Date(2021, time.February, 1, 23, 24, 25, 0, time.UTC)
+	BeforeEach(func() {
+		c = &Mock{Virtual: true, Default: Script{Now: ms}}
+		c.Start(tm)
+	})
+
+	It("carries the clock", func() {
+		ctx := WithClock(context.Background(), c)
+		Expect(FromContext(ctx)).To(BeIdenticalTo(c))
+		Expect(Now(ctx)).To(Equal(tm.Add(ms)))
+		Expect(Since(ctx, tm)).To(Equal(2 * ms))
+		Expect(Until(ctx, tm)).To(Equal(-3 * ms))
+		NewTimer(ctx, time.Second).Stop()
+		NewTicker(ctx, time.Second).Stop()
+		ch := After(ctx, time.Second)
+		AfterFunc(ctx, time.Second, func() {}).Stop()
+		c.Advance(time.Second)
+		Expect(ch).To(Receive())
+		c.Stop()
+
+		Expect(c.Calls()).To(Equal([]string{
+			"now",
+			"since",
+			"until",
+			"timer 1s",
+			"timer-1.stop",
+			"ticker 1s",
+			"ticker-1.stop",
+			"after 1s",
+			"afterfunc 1s",
+			"afterfunc-3.stop",
+		}))
+		Expect(FromContext(context.Background()).Now()).To(
+			BeTemporally("~", time.Now(), time.Second))
+	})
+
+	It("times out by the clock", func() {
+		ctx, cancel := WithTimeout(context.Background(), c, time.Second)
+		defer cancel()
+		child, cancelChild := context.WithCancel(ctx)
+		defer cancelChild()
+		d, ok := ctx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(d).To(Equal(tm.Add(ms + time.Second)))
+		Consistently(ctx.Done(), 20*ms).ShouldNot(BeClosed())
+		Expect(ctx.Err()).NotTo(HaveOccurred())
+
+		c.Advance(time.Second)
+		Eventually(ctx.Done()).Should(BeClosed())
+		Expect(ctx.Err()).To(MatchError(context.DeadlineExceeded))
+		Expect(context.Cause(ctx)).To(MatchError(context.DeadlineExceeded))
+		Eventually(child.Done()).Should(BeClosed())
+		Expect(child.Err()).To(MatchError(context.DeadlineExceeded))
+		c.Stop()
+
+		Expect(c.Calls()).To(Equal([]string{"now", "afterfunc 1s"}))
+	})
+
+	It("propagates the cause", func() {
+		errSlow := errors.New("slow")
+		ctx, cancel := WithDeadlineCause(context.Background(), c,
+			tm.Add(time.Second), errSlow)
+		defer cancel()
+		c.Advance(time.Second)
+		Eventually(ctx.Done()).Should(BeClosed())
+		Expect(ctx.Err()).To(MatchError(context.DeadlineExceeded))
+		Expect(context.Cause(ctx)).To(MatchError(errSlow))
+		c.Stop()
+	})
+
+	It("stops the timer on cancel", func() {
+		parent, cancelParent := context.WithCancelCause(context.Background())
+		ctx, cancel := WithTimeout(parent, c, time.Second)
+		defer cancel()
+		errGone := errors.New("gone")
+		cancelParent(errGone)
+		Eventually(ctx.Done()).Should(BeClosed())
+		Expect(ctx.Err()).To(MatchError(context.Canceled))
+		Expect(context.Cause(ctx)).To(MatchError(errGone))
+		Expect(c.Outstanding()).To(BeEmpty())
+
+		ctx, cancel = WithTimeout(context.Background(), c, time.Second)
+		cancel()
+		Expect(ctx.Done()).To(BeClosed())
+		Expect(ctx.Err()).To(MatchError(context.Canceled))
+		c.Stop()
+
+		Expect(c.Calls()).To(Equal([]string{
+			"now",
+			"afterfunc 1s",
+			"afterfunc-1.stop",
+			"now",
+			"afterfunc 1s",
+			"afterfunc-2.stop",
+		}))
+	})
+
+	It("keeps the earlier parent deadline", func() {
+		parent, cancelParent := WithTimeout(context.Background(), c,
+			time.Minute)
+		defer cancelParent()
+		ctx, cancel := WithTimeout(parent, c, time.Hour)
+		defer cancel()
+		d, ok := ctx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(d).To(Equal(tm.Add(ms + time.Minute)))
+
+		c.Advance(time.Minute)
+		Eventually(ctx.Done()).Should(BeClosed())
+		Expect(ctx.Err()).To(MatchError(context.DeadlineExceeded))
+		c.Stop()
+
+		Expect(c.Calls()).To(Equal([]string{
+			"now",
+			"afterfunc 1m0s",
+			"now",
+		}))
+	})
+
+	It("expires the past deadline", func() {
+		ctx, cancel := WithDeadline(context.Background(), c, tm)
+		defer cancel()
+		Expect(ctx.Done()).To(BeClosed())
+		Expect(ctx.Err()).To(MatchError(context.DeadlineExceeded))
+		c.Stop()
+		Expect(c.Calls()).To(Equal([]string{"until"}))
+	})
+})
@@ -0,0 +1,172 @@
+package clock
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Debug is a Clock wrapper recording the creation call stack of every Timer
+// and Ticker, so the leak can be found by Outstanding().
+type Debug struct {
+	Clock
+	objs    []*debugObj
+	prune   int
+	nTimer  int
+	nTicker int
+	lock    sync.Mutex
+}
+
+// NewDebug returns a new Debug wrapping c.
+func NewDebug(c Clock) *Debug {
+	return &Debug{Clock: c}
+}
+
+// Outstanding returns every active Timer and Ticker with its creation call
+// stack. A Timer is active until its duration has elapsed or stopped, a
+// Ticker is active until it's stopped.
+func (c *Debug) Outstanding() []Live {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.pruneObjs()
+	l := make([]Live, len(c.objs))
+	for i, o := range c.objs {
+		l[i] = liveOf(o.name, o.stack)
+	}
+	return l
+}
+
+func (c *Debug) NewTimer(d time.Duration) *Timer {
+	t := c.Clock.NewTimer(d)
+	t.Timerable = &debugTimer{t.Timerable, c.add("timer", d, callers())}
+	return t
+}
+
+func (c *Debug) AfterFunc(d time.Duration, f func()) *Timer {
+	t := c.Clock.AfterFunc(d, f)
+	t.Timerable = &debugTimer{t.Timerable, c.add("afterfunc", d, callers())}
+	return t
+}
+
+func (c *Debug) NewTicker(d time.Duration) *Ticker {
+	t := c.Clock.NewTicker(d)
+	t.Tickerable = &debugTicker{t.Tickerable, c.add("ticker", 0, callers())}
+	return t
+}
+
+func (c *Debug) add(name string, d time.Duration, pc []uintptr) *debugObj {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	o := &debugObj{debug: c, stack: pc}
+	if name == "ticker" {
+		c.nTicker++
+		o.name = name + "-" + strconv.Itoa(c.nTicker)
+		o.ticker = true
+	} else {
+		c.nTimer++
+		o.name = name + "-" + strconv.Itoa(c.nTimer)
+		o.until = current(c.Clock).Add(d)
+	}
+	c.list(o)
+	return o
+}
+
+// list must be called with c.lock held.
+func (c *Debug) list(o *debugObj) {
+	o.listed = true
+	if c.objs = append(c.objs, o); len(c.objs) > 2*c.prune {
+		c.pruneObjs()
+	}
+}
+
+// pruneObjs must be called with c.lock held.
+func (c *Debug) pruneObjs() {
+	now := current(c.Clock)
+	i := 0
+	for _, o := range c.objs {
+		if o.active(now) {
+			c.objs[i] = o
+			i++
+		} else {
+			o.listed = false
+		}
+	}
+	clear(c.objs[i:])
+	c.objs = c.objs[:i]
+	c.prune = max(i, 16)
+}
+
+// ===========================================================================
+
+type debugObj struct {
+	debug   *Debug
+	listed  bool // guarded by debug.lock
+	name    string
+	stack   []uintptr
+	until   time.Time // timer only
+	ticker  bool
+	stopped bool
+	lock    sync.Mutex
+}
+
+func (o *debugObj) active(now time.Time) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	return !o.stopped && (o.ticker || now.Before(o.until))
+}
+
+func (o *debugObj) stop() {
+	o.lock.Lock()
+	o.stopped = true
+	o.lock.Unlock()
+}
+
+func (o *debugObj) reset(d time.Duration) {
+	o.lock.Lock()
+	o.stopped = false
+	if !o.ticker {
+		o.until = current(o.debug.Clock).Add(d)
+	}
+	o.lock.Unlock()
+
+	o.debug.lock.Lock()
+	if !o.listed {
+		o.debug.list(o)
+	}
+	o.debug.lock.Unlock()
+}
+
+type debugTimer struct {
+	Timerable
+	*debugObj
+}
+
+func (t *debugTimer) Stop() bool {
+	ret := t.Timerable.Stop()
+	t.stop()
+	return ret
+}
+
+func (t *debugTimer) Reset(d time.Duration) bool {
+	ret := t.Timerable.Reset(d)
+	t.reset(d)
+	return ret
+}
+
+type debugTicker struct {
+	Tickerable
+	*debugObj
+}
+
+func (t *debugTicker) Stop() {
+	t.Tickerable.Stop()
+	t.stop()
+}
+
+func (t *debugTicker) Reset(d time.Duration) {
+	t.Tickerable.Reset(d)
+	t.reset(d)
+}
@@ -0,0 +1,56 @@
+package clock_test
+
+import (
+	"time"
+
+	. "github.com/bangzek/clock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Debug", func() {
+	It("lists the active Timer and Ticker", func() {
+		c := NewDebug(New())
+		t1 := c.NewTimer(time.Hour)
+		t2 := c.NewTicker(time.Hour)
+		c.NewTimer(ms)
+		t4 := c.AfterFunc(time.Hour, func() {})
+		t5 := c.NewTicker(time.Hour)
+		t4.Stop()
+		t5.Stop()
+		time.Sleep(5 * ms)
+
+		l := c.Outstanding()
+		Expect(l).To(HaveExactElements(
+			HaveField("Name", "timer-1"),
+			HaveField("Name", "ticker-1"),
+		))
+		Expect(l[0].Site).To(MatchRegexp(`/debug_test.go:\d+$`))
+		Expect(l[0].Stack).To(ContainSubstring("debug_test.go"))
+		Expect(l[1].String()).To(MatchRegexp(
+			`^ticker-1 created at .*/debug_test.go:\d+$`))
+
+		t1.Stop()
+		t2.Stop()
+		Expect(c.Outstanding()).To(BeEmpty())
+		t1.Reset(time.Hour)
+		Expect(c.Outstanding()).To(HaveExactElements(
+			HaveField("Name", "timer-1")))
+		t1.Stop()
+	})
+
+	It("doesn't call the Mock Now()", func() {
+		m := &Mock{Virtual: true}
+		m.Start(time.Now())
+		c := NewDebug(m)
+		t := c.NewTimer(time.Second)
+		t.Reset(time.Second)
+		t.Stop()
+		m.Stop()
+		Expect(m.Calls()).To(Equal([]string{
+			"timer 1s",
+			"timer-1.reset 1s",
+			"timer-1.stop",
+		}))
+	})
+})
@@ -0,0 +1,359 @@
+package clock
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Derived is a Clock running at an offset and a speed factor from its base
+// Clock, see NewOffset(), NewScaled() and Child(). Its Timer and Ticker fire
+// by its own time, and follow the change of offset or factor. Pausing it
+// stops its time, so every pending Timer/Ticker is frozen until resumed.
+//
+// The base time is read by its Current() when it has one like Mock, so
+// nothing is logged nor advanced. Every Timer/Ticker keeps one base AfterFunc
+// Timer, and the channel receives the deadline like Mock.
+type Derived struct {
+	base   Clock
+	anchor time.Time // base time
+	view   time.Time // derived time at anchor
+	factor float64
+	paused bool
+	timers map[*derivedTimer]struct{}
+	lock   sync.Mutex
+}
+
+// NewOffset returns a Derived running at base time plus offset, e.g. 72h to
+// pretend it's 3 days later.
+func NewOffset(base Clock, offset time.Duration) *Derived {
+	now := current(base)
+	return newDerived(base, now, now.Add(offset), 1)
+}
+
+// NewScaled returns a Derived running factor times faster than base since
+// epoch, so it's epoch at base epoch time.
+func NewScaled(base Clock, factor float64, epoch time.Time) *Derived {
+	checkFactor(factor)
+	return newDerived(base, epoch, epoch, factor)
+}
+
+func newDerived(base Clock, anchor, view time.Time, factor float64) *Derived {
+	return &Derived{
+		base:   base,
+		anchor: anchor,
+		view:   view.Round(0),
+		factor: factor,
+		timers: make(map[*derivedTimer]struct{}),
+	}
+}
+
+// ChildOption is the option for Child.
+type ChildOption func(*Derived)
+
+// ChildOffset sets the Child initial offset from its parent.
+func ChildOffset(offset time.Duration) ChildOption {
+	return func(c *Derived) { c.view = c.view.Add(offset) }
+}
+
+// ChildFactor sets the Child initial speed factor.
+func ChildFactor(factor float64) ChildOption {
+	checkFactor(factor)
+	return func(c *Derived) { c.factor = factor }
+}
+
+// ChildPaused starts the Child paused.
+func ChildPaused() ChildOption {
+	return func(c *Derived) { c.paused = true }
+}
+
+// Child returns a Derived of parent, which can be Mock or another Derived.
+// It runs at parent time unless the options say otherwise.
+func Child(parent Clock, opts ...ChildOption) *Derived {
+	now := current(parent)
+	c := newDerived(parent, now, now, 1)
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func checkFactor(factor float64) {
+	if !(factor > 0) || math.IsInf(factor, 0) {
+		panic("non-positive or infinite factor for clock.Derived")
+	}
+}
+
+// Offset returns the current offset from the base time.
+func (c *Derived) Offset() time.Duration {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := current(c.base)
+	return c.at(now).Sub(now.Round(0))
+}
+
+// SetOffset sets the current offset from the base time, the factor and
+// pause are unchanged.
+func (c *Derived) SetOffset(offset time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := current(c.base)
+	c.anchor, c.view = now, now.Round(0).Add(offset)
+	c.rescheduleAll(now)
+}
+
+// Pause stops the time, every pending Timer/Ticker is frozen.
+func (c *Derived) Pause() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.paused {
+		return
+	}
+	now := current(c.base)
+	c.anchor, c.view = now, c.at(now)
+	c.paused = true
+	for t := range c.timers {
+		t.stopBase()
+	}
+}
+
+// Resume continues the time from where it was paused.
+func (c *Derived) Resume() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.paused {
+		return
+	}
+	now := current(c.base)
+	c.anchor = now
+	c.paused = false
+	c.rescheduleAll(now)
+}
+
+// Paused reports whether the time is paused.
+func (c *Derived) Paused() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.paused
+}
+
+// Factor returns the current speed factor.
+func (c *Derived) Factor() float64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.factor
+}
+
+// SetFactor sets the speed factor from now on, the current time is
+// unchanged.
+func (c *Derived) SetFactor(factor float64) {
+	checkFactor(factor)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := current(c.base)
+	c.anchor, c.view = now, c.at(now)
+	c.factor = factor
+	c.rescheduleAll(now)
+}
+
+func (c *Derived) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.at(current(c.base))
+}
+
+func (c *Derived) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+func (c *Derived) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+func (c *Derived) Sleep(d time.Duration) {
+	<-c.NewTimer(d).C
+}
+
+func (c *Derived) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C
+}
+
+func (c *Derived) NewTimer(d time.Duration) *Timer {
+	t := &derivedTimer{clock: c, tc: newTimerChan(true, false)}
+	t.Reset(d)
+	return &Timer{Timerable: t, C: t.tc.ch}
+}
+
+func (c *Derived) AfterFunc(d time.Duration, f func()) *Timer {
+	t := &derivedTimer{clock: c, f: f}
+	t.Reset(d)
+	return &Timer{Timerable: t}
+}
+
+func (c *Derived) NewTicker(d time.Duration) *Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	t := &derivedTimer{clock: c, tc: newTimerChan(true, false)}
+	t.reset(d, d)
+	return &Ticker{Tickerable: (*derivedTicker)(t), C: t.tc.ch}
+}
+
+// at must be called with c.lock held, it returns the derived time of base
+// time b.
+func (c *Derived) at(b time.Time) time.Time {
+	if c.paused {
+		return c.view
+	}
+	return c.view.Add(time.Duration(float64(b.Sub(c.anchor)) * c.factor))
+}
+
+// baseDuration must be called with c.lock held, it returns the base
+// duration of derived duration d.
+func (c *Derived) baseDuration(d time.Duration) time.Duration {
+	return time.Duration(math.Ceil(float64(d) / c.factor))
+}
+
+// rescheduleAll must be called with c.lock held.
+func (c *Derived) rescheduleAll(now time.Time) {
+	for t := range c.timers {
+		t.schedule(now)
+	}
+}
+
+// ===========================================================================
+
+// derivedTimer fields are guarded by clock.lock.
+type derivedTimer struct {
+	clock  *Derived
+	tc     timerChan
+	f      func()
+	when   time.Time
+	period time.Duration
+	timer  *Timer // base AfterFunc, nil until scheduled
+	armed  bool   // the base timer is pending
+	stale  int    // the base timer expires to ignore
+}
+
+// reset arms the timer to fire after d, it returns whether it was active or
+// the unreceived value is dropped.
+func (t *derivedTimer) reset(d, period time.Duration) bool {
+	c := t.clock
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	ret := t.disarm()
+	now := current(c.base)
+	t.when = c.at(now).Add(d)
+	t.period = period
+	c.timers[t] = struct{}{}
+	t.schedule(now)
+	return ret
+}
+
+// disarm must be called with clock.lock held, it returns whether it was
+// active or the unreceived value was dropped.
+func (t *derivedTimer) disarm() bool {
+	_, ret := t.clock.timers[t]
+	delete(t.clock.timers, t)
+	t.stopBase()
+	if t.tc.drain() {
+		ret = true
+	}
+	return ret
+}
+
+// stopBase must be called with clock.lock held. When the base timer has
+// expired but expire() hasn't run yet, that expire() is ignored.
+func (t *derivedTimer) stopBase() {
+	if t.armed && !t.timer.Stop() {
+		t.stale++
+	}
+	t.armed = false
+}
+
+// schedule must be called with clock.lock held, now is the base time. It
+// fires right away when it's due, or waits for Resume() when paused.
+func (t *derivedTimer) schedule(now time.Time) {
+	c := t.clock
+	t.stopBase()
+	left := t.when.Sub(c.at(now))
+	if left <= 0 {
+		t.fire(now)
+		return
+	}
+	if c.paused {
+		return
+	}
+	t.armed = true
+	if t.timer == nil {
+		t.timer = c.base.AfterFunc(c.baseDuration(left), t.expire)
+	} else {
+		t.timer.Reset(c.baseDuration(left))
+	}
+}
+
+// expire runs on base AfterFunc, it does nothing if the base timer has been
+// stopped or reset since.
+func (t *derivedTimer) expire() {
+	c := t.clock
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if t.stale > 0 {
+		t.stale--
+		return
+	}
+	t.armed = false
+	t.schedule(current(c.base))
+}
+
+// fire must be called with clock.lock held, now is the base time. Like
+// Mock, the channel receives the deadline.
+func (t *derivedTimer) fire(now time.Time) {
+	c := t.clock
+	when := t.when
+	if t.period > 0 {
+		t.when = nextTick(when, c.at(now), t.period)
+		t.schedule(now)
+	} else {
+		delete(c.timers, t)
+	}
+	if t.f != nil {
+		go t.f()
+	} else {
+		t.tc.send(when)
+	}
+}
+
+func (t *derivedTimer) Stop() bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+
+	return t.disarm()
+}
+
+func (t *derivedTimer) Reset(d time.Duration) bool {
+	return t.reset(d, 0)
+}
+
+type derivedTicker derivedTimer
+
+func (t *derivedTicker) Stop() {
+	(*derivedTimer)(t).Stop()
+}
+
+func (t *derivedTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+	(*derivedTimer)(t).reset(d, d)
+}
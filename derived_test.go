@@ -0,0 +1,139 @@
+package clock_test
+
+import (
+	"time"
+
+	. "github.com/bangzek/clock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Derived", func() {
+	var c *Frozen
+	tm := time.Date(2021, time.February, 1, 23, 24, 25, 0, time.UTC)
+	BeforeEach(func() { c = NewFrozen(tm) })
+	advance := func(d time.Duration) {
+		c.Advance(d)
+		c.Wait()
+	}
+
+	Describe("NewOffset", func() {
+		It("runs at the offset", func() {
+			d := NewOffset(c, time.Hour)
+			Expect(d.Now()).To(BeTemporally("==", tm.Add(time.Hour)))
+			Expect(d.Offset()).To(Equal(time.Hour))
+
+			t := d.NewTimer(time.Hour)
+			advance(30 * time.Minute)
+			Expect(t.C).NotTo(Receive())
+			d.SetOffset(2 * time.Hour)
+			Expect(d.Offset()).To(Equal(2 * time.Hour))
+			Expect(d.Now()).To(BeTemporally("==", tm.Add(150*time.Minute)))
+			Expect(t.C).To(Receive(BeTemporally("==", tm.Add(2*time.Hour))))
+			Expect(t.Stop()).To(BeFalse())
+		})
+	})
+
+	Describe("Child", func() {
+		It("pauses and resumes", func() {
+			d := Child(c, ChildOffset(time.Hour))
+			t := d.NewTimer(time.Minute)
+			advance(30 * time.Second)
+			d.Pause()
+			Expect(d.Paused()).To(BeTrue())
+			now := d.Now()
+			Expect(now).To(BeTemporally("==",
+				tm.Add(time.Hour+30*time.Second)))
+			advance(time.Hour)
+			Expect(t.C).NotTo(Receive())
+			Expect(d.Now()).To(Equal(now))
+
+			d.Resume()
+			advance(20 * time.Second)
+			Expect(t.C).NotTo(Receive())
+			advance(10 * time.Second)
+			Expect(t.C).To(Receive(BeTemporally("==",
+				tm.Add(time.Hour+time.Minute))))
+		})
+
+		It("derives from another child", func() {
+			p := Child(c, ChildFactor(2))
+			d := Child(p, ChildFactor(3), ChildPaused())
+			tk := d.NewTicker(6 * time.Second)
+			advance(time.Hour)
+			Expect(tk.C).NotTo(Receive())
+
+			// the parent AfterFunc callback is not waited by Wait()
+			d.Resume()
+			advance(time.Second)
+			Eventually(tk.C).Should(Receive())
+			p.Pause()
+			advance(time.Hour)
+			Consistently(tk.C, 20*ms).ShouldNot(Receive())
+			p.Resume()
+			advance(time.Second)
+			Eventually(tk.C).Should(Receive())
+			tk.Stop()
+		})
+
+		It("keeps one base Timer on Mock", func() {
+			m := &Mock{Virtual: true}
+			m.Start(tm)
+			d := Child(m, ChildOffset(time.Hour))
+			tk := d.NewTicker(time.Minute)
+			Expect(d.Now()).To(Equal(m.Current().Add(time.Hour)))
+			d.SetFactor(2)
+			d.Pause()
+			d.Resume()
+			m.Advance(time.Minute)
+			m.Wait()
+			Eventually(tk.C).Should(Receive())
+			tk.Stop()
+			m.Stop()
+			calls := m.Calls()
+			Expect(calls[0]).To(Equal("afterfunc 1m0s"))
+			Expect(calls[1:]).To(HaveEach(HavePrefix("afterfunc-1.")))
+		})
+
+		It("runs on real clock", func() {
+			d := Child(New(), ChildFactor(100))
+			t := d.NewTimer(time.Second)
+			Eventually(t.C).Should(Receive())
+		})
+	})
+
+	Describe("NewScaled", func() {
+		It("runs faster", func() {
+			d := NewScaled(c, 60, tm)
+			Expect(d.Factor()).To(Equal(60.0))
+			advance(time.Minute)
+			Expect(d.Now()).To(BeTemporally("==", tm.Add(time.Hour)))
+
+			t := d.NewTimer(time.Hour)
+			tk := d.NewTicker(10 * time.Minute)
+			advance(10 * time.Second)
+			Expect(tk.C).To(Receive(BeTemporally("==",
+				tm.Add(70*time.Minute))))
+			Expect(t.C).NotTo(Receive())
+
+			d.SetFactor(120)
+			Expect(d.Now()).To(BeTemporally("==", tm.Add(70*time.Minute)))
+			advance(25 * time.Second)
+			Expect(t.C).To(Receive(BeTemporally("==",
+				tm.Add(2*time.Hour))))
+			Expect(tk.C).To(Receive(BeTemporally("==",
+				tm.Add(80*time.Minute))), "missed ticks dropped")
+
+			done := make(chan struct{})
+			d.AfterFunc(time.Hour, func() { close(done) })
+			advance(30 * time.Second)
+			Eventually(done).Should(BeClosed())
+			tk.Stop()
+		})
+
+		It("panics on non-positive factor", func() {
+			Expect(func() { NewScaled(c, 0, tm) }).To(PanicWith(
+				"non-positive or infinite factor for clock.Derived"))
+		})
+	})
+})
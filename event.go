@@ -0,0 +1,96 @@
+package clock
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Kind is the kind of Mock Event.
+type Kind byte
+
+const (
+	// Now(), Since() or Until() call.
+	KindNow Kind = iota
+	// New Timer/Ticker, Sleep(), After() or AfterFunc() call.
+	KindNew
+	// Timer/Ticker Reset() call.
+	KindReset
+	// Timer/Ticker Stop() call.
+	KindStop
+	// Timer/Ticker fired, this is not a call so it's not in Mock.Calls().
+	KindFire
+)
+
+var kindNames = [...]string{"now", "new", "reset", "stop", "fire"}
+
+func (k Kind) String() string {
+	if int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (k Kind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (k *Kind) UnmarshalText(b []byte) error {
+	for i, name := range kindNames {
+		if string(b) == name {
+			*k = Kind(i)
+			return nil
+		}
+	}
+	return errors.New("clock: unknown event kind " + strconv.Quote(string(b)))
+}
+
+// Event is a Mock log entry.
+type Event struct {
+	// The kind of event.
+	Kind Kind
+	// The name of the call or object: "now", "since", "until", "sleep",
+	// "timer", "after", "afterfunc" or "ticker".
+	Name string
+	// The label of the object, see Labeled().
+	Label string
+	// The object number as in Calls(), 0 for Now. The labeled object is
+	// numbered within its label, and Sleep is numbered on its own.
+	No int
+	// The duration argument.
+	Duration time.Duration
+	// The mocked time after the event.
+	Time time.Time
+	// The real time of the event.
+	Real time.Time
+	// The return value of Timer Reset() and Stop().
+	Ret bool
+}
+
+// String returns the event as in Mock.Calls(), e.g. "timer 1s",
+// "timer-2.reset 500ms" or "now". The labeled object is named by its label,
+// e.g. "timer retry 1s" and "retry-1.reset 2s".
+func (e Event) String() string {
+	switch e.Kind {
+	case KindNow:
+		return e.Name
+	case KindNew:
+		if e.Label != "" {
+			return e.Name + " " + e.Label + " " + e.Duration.String()
+		}
+		return e.Name + " " + e.Duration.String()
+	case KindReset:
+		return e.object() + ".reset " + e.Duration.String()
+	default:
+		return e.object() + "." + e.Kind.String()
+	}
+}
+
+func (e Event) object() string {
+	if e.Label != "" {
+		return e.Label + "-" + strconv.Itoa(e.No)
+	}
+	return e.Name + "-" + strconv.Itoa(e.No)
+}
@@ -0,0 +1,185 @@
+package clock
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Expectation is an expected Mock call, see Mock.Expect().
+type Expectation struct {
+	call  string
+	any   bool
+	group []Expectation
+}
+
+// Call expects exactly one call as in Mock.Calls(), e.g. "timer 5s",
+// "timer-1.reset 1s" or "timer-1.stop".
+func Call(call string) Expectation {
+	return Expectation{call: call}
+}
+
+// AnyTimes expects any number of the call, including none.
+// It's greedy, so the same call expected right after it is never matched.
+func AnyTimes(call string) Expectation {
+	return Expectation{call: call, any: true}
+}
+
+// Unordered expects all of the Call() and AnyTimes() in any order.
+func Unordered(exps ...Expectation) Expectation {
+	for _, e := range exps {
+		if e.group != nil {
+			panic("clock.Unordered: nested Unordered is not supported")
+		}
+	}
+	return Expectation{group: slices.Clone(exps)}
+}
+
+func (e Expectation) required() bool {
+	if e.group == nil {
+		return !e.any
+	}
+	return slices.ContainsFunc(e.group, Expectation.required)
+}
+
+func (e Expectation) String() string {
+	if e.group != nil {
+		l := make([]string, len(e.group))
+		for i, g := range e.group {
+			l[i] = g.String()
+		}
+		return "in any order {" + strings.Join(l, ", ") + "}"
+	}
+	if e.any {
+		return "any number of " + strconv.Quote(e.call)
+	}
+	return strconv.Quote(e.call)
+}
+
+// ===========================================================================
+
+type expecter struct {
+	steps  []Expectation
+	calls  []string
+	failed bool
+}
+
+// match returns the failure message or empty string.
+func (x *expecter) match(call string) string {
+	if x.failed {
+		return ""
+	}
+
+	steps := x.steps
+	for len(x.steps) > 0 {
+		st := &x.steps[0]
+		if st.group == nil {
+			if st.call == call {
+				if !st.any {
+					x.steps = x.steps[1:]
+				}
+				x.calls = append(x.calls, call)
+				return ""
+			}
+			if st.any {
+				x.steps = x.steps[1:]
+				continue
+			}
+			break
+		}
+
+		if i := slices.IndexFunc(st.group, func(e Expectation) bool {
+			return e.call == call
+		}); i >= 0 {
+			if !st.group[i].any {
+				st.group = slices.Delete(st.group, i, i+1)
+			}
+			if len(st.group) == 0 {
+				x.steps = x.steps[1:]
+			}
+			x.calls = append(x.calls, call)
+			return ""
+		}
+		if st.required() {
+			break
+		}
+		x.steps = x.steps[1:]
+	}
+
+	x.steps = steps
+	x.failed = true
+	var sb strings.Builder
+	sb.WriteString("unexpected call ")
+	sb.WriteString(strconv.Quote(call))
+	sb.WriteString(" at ")
+	sb.WriteString(caller())
+	for _, c := range x.calls {
+		sb.WriteString("\n  ")
+		sb.WriteString(c)
+	}
+	if len(steps) > 0 {
+		sb.WriteString("\n- ")
+		sb.WriteString(steps[0].String())
+	} else {
+		sb.WriteString("\n- (no more call)")
+	}
+	sb.WriteString("\n+ ")
+	sb.WriteString(strconv.Quote(call))
+	return sb.String()
+}
+
+// missing returns the failure message for unmet expectations or empty string.
+func (x *expecter) missing() string {
+	if x.failed || !slices.ContainsFunc(x.steps, Expectation.required) {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("missing expected calls:")
+	for _, e := range x.steps {
+		sb.WriteString("\n- ")
+		sb.WriteString(e.String())
+	}
+	return sb.String()
+}
+
+// ===========================================================================
+
+// Expect appends the expected calls in order, each call is checked as it
+// happens and the first deviation is reported with the calls so far. The
+// unmet expectations are reported on Stop().
+//
+//	m.Expect(
+//		clock.Call("timer 5s"),
+//		clock.AnyTimes("now"),
+//		clock.Unordered(
+//			clock.Call("timer-1.reset 1s"),
+//			clock.Call("ticker 1s"),
+//		),
+//		clock.Call("timer-1.stop"),
+//	)
+func (m *Mock) Expect(exps ...Expectation) {
+	m.cLock.Lock()
+	defer m.cLock.Unlock()
+
+	if m.expecter == nil {
+		m.expecter = new(expecter)
+	}
+	for _, e := range exps {
+		e.group = slices.Clone(e.group)
+		m.expecter.steps = append(m.expecter.steps, e)
+	}
+}
+
+func (m *Mock) checkMissing() {
+	m.cLock.Lock()
+	var msg string
+	if m.expecter != nil {
+		msg = m.expecter.missing()
+	}
+	m.cLock.Unlock()
+
+	if msg != "" {
+		m.fail("%s", msg)
+	}
+}
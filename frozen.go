@@ -0,0 +1,198 @@
+package clock
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Frozen is a Clock whose time only moves by Set() or Advance(), so Now()
+// always returns the same time in between. Unlike Mock, nothing is logged
+// and it's safe for concurrent use.
+//
+// The Timer/Ticker only fire by Set() or Advance(), AfterFunc callback runs
+// in its own goroutine like [time.AfterFunc] and can be waited by Wait().
+// Like Go 1.23, Reset() and Stop() drop the unreceived Timer/Ticker value.
+type Frozen struct {
+	now    time.Time
+	timers []*frozenTimer // active only
+	funcs  funcGroup
+	lock   sync.Mutex
+}
+
+// NewFrozen returns a new Frozen at time t.
+func NewFrozen(t time.Time) *Frozen {
+	return &Frozen{now: t}
+}
+
+// Set sets the time to t and fires every Timer/Ticker whose deadline is not
+// after t, in deadline order. The time can be set backward, but nothing
+// fires then.
+func (c *Frozen) Set(t time.Time) {
+	for {
+		c.lock.Lock()
+		ft := c.nextDue(t)
+		if ft == nil {
+			c.now = t
+			c.lock.Unlock()
+			return
+		}
+		if ft.when.After(c.now) {
+			c.now = ft.when
+		}
+		ft.fire(t)
+		c.lock.Unlock()
+	}
+}
+
+// Wait blocks until every AfterFunc callback that has been fired returns.
+// Calling this after Advance() or Set() make sure every callback due has
+// done.
+func (c *Frozen) Wait() {
+	c.funcs.wait()
+}
+
+// Advance moves the time by d, see Set().
+func (c *Frozen) Advance(d time.Duration) {
+	c.lock.Lock()
+	t := c.now.Add(d)
+	c.lock.Unlock()
+	c.Set(t)
+}
+
+// nextDue must be called with c.lock held.
+func (c *Frozen) nextDue(t time.Time) *frozenTimer {
+	var due *frozenTimer
+	for _, ft := range c.timers {
+		if !ft.when.After(t) && (due == nil || ft.when.Before(due.when)) {
+			due = ft
+		}
+	}
+	return due
+}
+
+func (c *Frozen) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.now
+}
+
+func (c *Frozen) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+func (c *Frozen) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+func (c *Frozen) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	<-c.NewTimer(d).C
+}
+
+func (c *Frozen) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C
+}
+
+func (c *Frozen) NewTimer(d time.Duration) *Timer {
+	t := &frozenTimer{clock: c, tc: newTimerChan(true, false)}
+	t.reset(d, 0)
+	return &Timer{Timerable: t, C: t.tc.ch}
+}
+
+func (c *Frozen) AfterFunc(d time.Duration, f func()) *Timer {
+	t := &frozenTimer{clock: c, f: f}
+	t.reset(d, 0)
+	return &Timer{Timerable: t}
+}
+
+func (c *Frozen) NewTicker(d time.Duration) *Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	t := &frozenTimer{clock: c, tc: newTimerChan(true, false)}
+	t.reset(d, d)
+	return &Ticker{Tickerable: (*frozenTicker)(t), C: t.tc.ch}
+}
+
+// ===========================================================================
+
+// frozenTimer fields are guarded by clock.lock.
+type frozenTimer struct {
+	clock  *Frozen
+	tc     timerChan
+	f      func()
+	when   time.Time
+	period time.Duration
+	active bool
+}
+
+func (t *frozenTimer) reset(d, period time.Duration) bool {
+	c := t.clock
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	ret := t.disarm()
+	t.when = c.now.Add(d)
+	t.period = period
+	t.active = true
+	c.timers = append(c.timers, t)
+	return ret
+}
+
+// disarm must be called with clock.lock held, it returns whether it was
+// active or the unreceived value was dropped.
+func (t *frozenTimer) disarm() bool {
+	ret := t.active
+	if ret {
+		t.active = false
+		t.clock.timers = slices.DeleteFunc(t.clock.timers,
+			func(ft *frozenTimer) bool { return ft == t })
+	}
+	if t.tc.drain() {
+		ret = true
+	}
+	return ret
+}
+
+// fire must be called with clock.lock held, now is the time being set.
+func (t *frozenTimer) fire(now time.Time) {
+	when := t.when
+	if t.period > 0 {
+		t.when = nextTick(when, now, t.period)
+	} else {
+		t.disarm()
+	}
+	if t.f != nil {
+		t.clock.funcs.run(t.f)
+	} else {
+		t.tc.send(when)
+	}
+}
+
+func (t *frozenTimer) Stop() bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+
+	return t.disarm()
+}
+
+func (t *frozenTimer) Reset(d time.Duration) bool {
+	return t.reset(d, 0)
+}
+
+type frozenTicker frozenTimer
+
+func (t *frozenTicker) Stop() {
+	(*frozenTimer)(t).Stop()
+}
+
+func (t *frozenTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+	(*frozenTimer)(t).reset(d, d)
+}
@@ -0,0 +1,101 @@
+package clock_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/bangzek/clock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Frozen", func() {
+	var c *Frozen
+	tm := time.Date(2021, time.February, 1, 23, 24, 25, 0, time.UTC)
+	BeforeEach(func() { c = NewFrozen(tm) })
+
+	It("returns the same time until moved", func() {
+		Expect(c.Now()).To(Equal(tm))
+		Expect(c.Now()).To(Equal(tm))
+		Expect(c.Since(tm)).To(BeZero())
+		c.Advance(time.Second)
+		Expect(c.Now()).To(Equal(tm.Add(time.Second)))
+		c.Set(tm)
+		Expect(c.Now()).To(Equal(tm))
+	})
+
+	It("fires on advance only", func() {
+		t := c.NewTimer(time.Second)
+		tk := c.NewTicker(300 * ms)
+		var n atomic.Int32
+		c.AfterFunc(500*ms, func() { n.Add(1) })
+		Consistently(t.C, 20*ms).ShouldNot(Receive())
+
+		c.Advance(time.Second)
+		c.Wait()
+		Expect(t.C).To(Receive(Equal(tm.Add(time.Second))))
+		Expect(tk.C).To(Receive(Equal(tm.Add(300 * ms))))
+		Expect(n.Load()).To(BeEquivalentTo(1))
+
+		Expect(t.Reset(time.Second)).To(BeFalse())
+		Expect(t.Stop()).To(BeTrue())
+		tk.Stop()
+		c.Advance(time.Hour)
+		Expect(t.C).NotTo(Receive())
+		Expect(tk.C).NotTo(Receive())
+	})
+
+	It("runs AfterFunc callback in its own goroutine", func() {
+		ch := make(chan time.Time)
+		c.AfterFunc(time.Second, func() { ch <- tm })
+		c.Advance(time.Second)
+		Expect(<-ch).To(Equal(tm))
+		c.Wait()
+	})
+
+	It("wakes the Sleep", func() {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			c.Sleep(time.Second)
+		}()
+		Consistently(done, 20*ms).ShouldNot(BeClosed())
+		c.Advance(time.Second)
+		Eventually(done).Should(BeClosed())
+	})
+
+	It("drops the missed ticks", func() {
+		tk := c.NewTicker(ms)
+		c.Advance(24 * time.Hour)
+		Expect(tk.C).To(Receive(Equal(tm.Add(ms))))
+		c.Advance(ms)
+		Expect(tk.C).To(Receive(Equal(tm.Add(24*time.Hour + ms))))
+		tk.Stop()
+	})
+
+	It("returns Sleep at once on non-positive duration", func() {
+		c.Sleep(0)
+		c.Sleep(-time.Second)
+		Expect(c.Now()).To(Equal(tm))
+	})
+
+	It("is safe for concurrent use", func() {
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 100 {
+					c.Now()
+					c.NewTimer(ms).Stop()
+				}
+			}()
+		}
+		for range 100 {
+			c.Advance(ms)
+		}
+		wg.Wait()
+		Expect(c.Now()).To(Equal(tm.Add(100 * ms)))
+	})
+})
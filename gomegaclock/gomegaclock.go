@@ -0,0 +1,220 @@
+// Package gomegaclock provides Gomega matchers for clock assertions.
+package gomegaclock
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/bangzek/clock"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// HaveCalled succeeds if actual *clock.Mock or []string has the call as in
+// clock.Mock.Calls(), e.g. "timer-1.stop".
+func HaveCalled(call string) types.GomegaMatcher {
+	return &haveCalledMatcher{call: call}
+}
+
+// HaveCallSequence succeeds if actual *clock.Mock or []string has the calls
+// in order, other calls in between are allowed.
+func HaveCallSequence(calls ...string) types.GomegaMatcher {
+	return &haveCallSequenceMatcher{seq: calls}
+}
+
+// FireWithin succeeds if actual *clock.Timer or *clock.Ticker created by
+// clock.Mock fires within virtual duration d from the current mocked time,
+// or has fired and its value is not received yet.
+func FireWithin(d time.Duration) types.GomegaMatcher {
+	return &fireWithinMatcher{d: d}
+}
+
+// HaveTimesSpacedBy succeeds if every consecutive actual *clock.Mock Times()
+// or []time.Time are spaced by d within the tolerance.
+func HaveTimesSpacedBy(d, tolerance time.Duration) types.GomegaMatcher {
+	return &haveTimesSpacedByMatcher{d: d, tolerance: tolerance}
+}
+
+// ===========================================================================
+
+func callsOf(name string, actual any) ([]string, error) {
+	switch a := actual.(type) {
+	case *clock.Mock:
+		return a.SnapshotCalls(), nil
+	case []string:
+		return a, nil
+	}
+	return nil, fmt.Errorf(
+		"%s matcher expects a *clock.Mock or []string.  Got:\n%s",
+		name, format.Object(actual, 1))
+}
+
+func timesOf(name string, actual any) ([]time.Time, error) {
+	switch a := actual.(type) {
+	case *clock.Mock:
+		return a.SnapshotTimes(), nil
+	case []time.Time:
+		return a, nil
+	}
+	return nil, fmt.Errorf(
+		"%s matcher expects a *clock.Mock or []time.Time.  Got:\n%s",
+		name, format.Object(actual, 1))
+}
+
+// ===========================================================================
+
+type haveCalledMatcher struct {
+	call  string
+	calls []string
+}
+
+func (m *haveCalledMatcher) Match(actual any) (bool, error) {
+	calls, err := callsOf("HaveCalled", actual)
+	if err != nil {
+		return false, err
+	}
+	m.calls = calls
+	return slices.Contains(calls, m.call), nil
+}
+
+func (m *haveCalledMatcher) FailureMessage(any) string {
+	return format.Message(m.calls, "to have called", m.call)
+}
+
+func (m *haveCalledMatcher) NegatedFailureMessage(any) string {
+	return format.Message(m.calls, "not to have called", m.call)
+}
+
+// ===========================================================================
+
+type haveCallSequenceMatcher struct {
+	seq   []string
+	calls []string
+	found int
+}
+
+func (m *haveCallSequenceMatcher) Match(actual any) (bool, error) {
+	calls, err := callsOf("HaveCallSequence", actual)
+	if err != nil {
+		return false, err
+	}
+	m.calls = calls
+	m.found = 0
+	for _, c := range calls {
+		if m.found < len(m.seq) && c == m.seq[m.found] {
+			m.found++
+		}
+	}
+	return m.found == len(m.seq), nil
+}
+
+func (m *haveCallSequenceMatcher) FailureMessage(any) string {
+	msg := format.Message(m.calls, "to have call sequence", m.seq)
+	if m.found == 0 {
+		return msg + "\nbut " + strconv.Quote(m.seq[0]) + " is not called"
+	}
+	return msg + "\nbut " + strconv.Quote(m.seq[m.found]) +
+		" is not called after " + strconv.Quote(m.seq[m.found-1])
+}
+
+func (m *haveCallSequenceMatcher) NegatedFailureMessage(any) string {
+	return format.Message(m.calls, "not to have call sequence", m.seq)
+}
+
+// ===========================================================================
+
+// mocked is implemented by clock.Mock Timerable and Tickerable.
+type mocked interface {
+	Mock() *clock.Mock
+	Deadline() (time.Time, bool)
+	Unreceived() bool
+}
+
+type fireWithinMatcher struct {
+	d      time.Duration
+	name   string
+	active bool
+	left   time.Duration
+}
+
+func (m *fireWithinMatcher) Match(actual any) (bool, error) {
+	var x any
+	switch a := actual.(type) {
+	case *clock.Timer:
+		m.name, x = "Timer", a.Timerable
+	case *clock.Ticker:
+		m.name, x = "Ticker", a.Tickerable
+	default:
+		return false, fmt.Errorf(
+			"FireWithin matcher expects a *clock.Timer or *clock.Ticker."+
+				"  Got:\n%s", format.Object(actual, 1))
+	}
+	t, ok := x.(mocked)
+	if !ok {
+		return false, fmt.Errorf(
+			"FireWithin matcher expects a %s created by *clock.Mock", m.name)
+	}
+
+	if t.Unreceived() {
+		m.active, m.left = true, 0
+		return true, nil
+	}
+	var when time.Time
+	if when, m.active = t.Deadline(); !m.active {
+		return false, nil
+	}
+	m.left = when.Sub(t.Mock().Current())
+	return m.left <= m.d, nil
+}
+
+func (m *fireWithinMatcher) FailureMessage(any) string {
+	if !m.active {
+		return fmt.Sprintf("Expected %s to fire within %s, but it's not active",
+			m.name, m.d)
+	}
+	return fmt.Sprintf("Expected %s to fire within %s, but it fires in %s",
+		m.name, m.d, m.left)
+}
+
+func (m *fireWithinMatcher) NegatedFailureMessage(any) string {
+	return fmt.Sprintf("Expected %s not to fire within %s, but it fires in %s",
+		m.name, m.d, m.left)
+}
+
+// ===========================================================================
+
+type haveTimesSpacedByMatcher struct {
+	d         time.Duration
+	tolerance time.Duration
+	times     []time.Time
+	i         int
+}
+
+func (m *haveTimesSpacedByMatcher) Match(actual any) (bool, error) {
+	times, err := timesOf("HaveTimesSpacedBy", actual)
+	if err != nil {
+		return false, err
+	}
+	m.times = times
+	for m.i = 1; m.i < len(times); m.i++ {
+		diff := times[m.i].Sub(times[m.i-1]) - m.d
+		if diff < -m.tolerance || diff > m.tolerance {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (m *haveTimesSpacedByMatcher) FailureMessage(any) string {
+	return format.Message(m.times, "to be spaced by",
+		fmt.Sprintf("%s ± %s", m.d, m.tolerance)) +
+		fmt.Sprintf("\nbut times[%d] - times[%d] is %s",
+			m.i, m.i-1, m.times[m.i].Sub(m.times[m.i-1]))
+}
+
+func (m *haveTimesSpacedByMatcher) NegatedFailureMessage(any) string {
+	return format.Message(m.times, "not to be spaced by",
+		fmt.Sprintf("%s ± %s", m.d, m.tolerance))
+}
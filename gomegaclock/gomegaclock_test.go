@@ -0,0 +1,114 @@
+package gomegaclock_test
+
+import (
+	"time"
+
+	"github.com/bangzek/clock"
+	. "github.com/bangzek/clock/gomegaclock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Matchers", func() {
+	var c *clock.Mock
+	tm := time.Date(2021, time.February, 1, 23, 24, 25, 0, time.UTC)
+	BeforeEach(func() {
+		c = &clock.Mock{Virtual: true}
+		c.Start(tm)
+	})
+	AfterEach(func() { c.Stop() })
+
+	Describe("HaveCalled", func() {
+		It("matches the call", func() {
+			t := c.NewTimer(time.Second)
+			t.Stop()
+			Expect(c).To(HaveCalled("timer-1.stop"))
+			Expect(c).NotTo(HaveCalled("timer-1.reset 1s"))
+			Expect([]string{"now"}).To(HaveCalled("now"))
+		})
+
+		It("has readable failure message", func() {
+			m := HaveCalled("now")
+			Expect(m.Match([]string{"timer 1s"})).To(BeFalse())
+			Expect(m.FailureMessage(nil)).To(Equal(
+				"Expected\n    <[]string | len:1, cap:1>: [\"timer 1s\"]\n" +
+					"to have called\n    <string>: now"))
+		})
+
+		It("errors on other type", func() {
+			_, err := HaveCalled("now").Match(1)
+			Expect(err).To(MatchError(ContainSubstring(
+				"HaveCalled matcher expects a *clock.Mock or []string")))
+		})
+	})
+
+	Describe("HaveCallSequence", func() {
+		It("matches the calls in order", func() {
+			t := c.NewTimer(time.Second)
+			c.Now()
+			t.Reset(time.Second)
+			c.Now()
+			t.Stop()
+			Expect(c).To(HaveCallSequence(
+				"timer 1s", "timer-1.reset 1s", "timer-1.stop"))
+			Expect(c).NotTo(HaveCallSequence("timer-1.stop", "now"))
+		})
+
+		It("tells the missing call", func() {
+			m := HaveCallSequence("now", "timer 1s")
+			Expect(m.Match([]string{"timer 1s", "now"})).To(BeFalse())
+			Expect(m.FailureMessage(nil)).To(HaveSuffix(
+				`but "timer 1s" is not called after "now"`))
+		})
+	})
+
+	Describe("FireWithin", func() {
+		It("matches virtual Timer and Ticker", func() {
+			t1 := c.NewTimer(time.Second)
+			t2 := c.NewTicker(2 * time.Second)
+			Expect(t1).To(FireWithin(time.Second))
+			Expect(t2).NotTo(FireWithin(time.Second))
+			c.Advance(time.Second)
+			Expect(t1).To(FireWithin(0), "unreceived")
+			<-t1.C
+			Expect(t1).NotTo(FireWithin(time.Hour), "fired")
+			Expect(t2).To(FireWithin(time.Second))
+		})
+
+		It("tells when it fires", func() {
+			m := FireWithin(time.Second)
+			Expect(m.Match(c.NewTimer(2 * time.Second))).To(BeFalse())
+			Expect(m.FailureMessage(nil)).To(Equal(
+				"Expected Timer to fire within 1s, but it fires in 2s"))
+		})
+
+		It("errors on real Timer", func() {
+			_, err := FireWithin(time.Second).Match(
+				clock.New().NewTimer(time.Second))
+			Expect(err).To(MatchError(
+				"FireWithin matcher expects a Timer created by *clock.Mock"))
+		})
+	})
+
+	Describe("HaveTimesSpacedBy", func() {
+		It("matches spaced times", func() {
+			t := c.NewTicker(time.Second)
+			c.Advance(time.Second)
+			<-t.C
+			c.Advance(time.Second)
+			<-t.C
+			Expect(c.SnapshotTimes()[1:]).
+				To(HaveTimesSpacedBy(time.Second, 0))
+			Expect(c).To(HaveTimesSpacedBy(time.Second, time.Second))
+			Expect(c).NotTo(HaveTimesSpacedBy(2*time.Second, 10*ms))
+		})
+
+		It("tells the wrong spacing", func() {
+			m := HaveTimesSpacedBy(time.Second, ms)
+			Expect(m.Match([]time.Time{tm, tm.Add(time.Second),
+				tm.Add(3 * time.Second)})).To(BeFalse())
+			Expect(m.FailureMessage(nil)).To(HaveSuffix(
+				"but times[2] - times[1] is 2s"))
+		})
+	})
+})
@@ -0,0 +1,89 @@
+package clock
+
+import (
+	"strconv"
+	"time"
+)
+
+// Handle inspects and drives a Mock Timer/Ticker from the test.
+type Handle struct {
+	c *common
+}
+
+// Timer returns the handle of n-th Timer as in Calls(), e.g. 2 for
+// "timer-2". Please note After() and AfterFunc() are counted as Timer.
+func (m *Mock) Timer(n int) *Handle {
+	m.sLock.Lock()
+	defer m.sLock.Unlock()
+
+	if n < 1 || n > len(m.timers) {
+		panic("clock.Mock has no timer-" + strconv.Itoa(n))
+	}
+	return &Handle{&m.timers[n-1].common}
+}
+
+// Ticker returns the handle of n-th Ticker as in Calls(), e.g. 2 for
+// "ticker-2".
+func (m *Mock) Ticker(n int) *Handle {
+	m.sLock.Lock()
+	defer m.sLock.Unlock()
+
+	if n < 1 || n > len(m.tickers) {
+		panic("clock.Mock has no ticker-" + strconv.Itoa(n))
+	}
+	return &Handle{&m.tickers[n-1].common}
+}
+
+// Fire fires the active Timer/Ticker now regardless of its duration, and
+// reports whether it's fired. The fired Timer is no longer active, while
+// the Ticker schedule is unchanged.
+func (h *Handle) Fire() bool {
+	c := h.c
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.active {
+		return false
+	}
+	if c.period == 0 {
+		c.gen++
+		if c.fake != nil {
+			c.fake.Stop()
+		}
+		c.setActive(false)
+	}
+	c.deliver(c.mock.now())
+	return true
+}
+
+// Deadline returns the mocked time the Timer/Ticker will fire next and
+// whether it's active.
+func (h *Handle) Deadline() (time.Time, bool) {
+	return h.c.Deadline()
+}
+
+// Duration returns the duration of the last New or Reset call.
+func (h *Handle) Duration() time.Duration {
+	h.c.lock.Lock()
+	defer h.c.lock.Unlock()
+
+	return h.c.d
+}
+
+// Active reports whether the Timer/Ticker is active, a Timer is active until
+// it fires or stopped, a Ticker is active until it's stopped.
+func (h *Handle) Active() bool {
+	h.c.lock.Lock()
+	defer h.c.lock.Unlock()
+
+	return h.c.active
+}
+
+// FireCount returns how many times the Timer/Ticker has fired, including the
+// value dropped because it's not received.
+func (h *Handle) FireCount() int {
+	h.c.lock.Lock()
+	defer h.c.lock.Unlock()
+
+	return h.c.fires
+}
@@ -0,0 +1,31 @@
+package clock
+
+import "time"
+
+// Jump is a scripted wall clock step of Mock, like NTP correction.
+//
+// The jump happens before the Call-th Now(), Since() or Until() call, or
+// when the monotonic time reaches At if Call is zero. The monotonic time is
+// the mocked time without any jump, e.g. the Start() time plus Advance().
+type Jump struct {
+	// The Now(), Since() or Until() call number counted from 1 like
+	// NowScripts, zero to jump by At.
+	Call int
+	// The monotonic time to jump.
+	At time.Time
+	// How much the wall clock moves, it can be negative.
+	Offset time.Duration
+}
+
+// skew must be called with m.tLock held, it returns the wall clock offset
+// from the monotonic time by the jumps happened so far.
+func (m *Mock) skew() time.Duration {
+	var d time.Duration
+	for _, j := range m.Jumps {
+		if j.Call > 0 && m.iNow >= j.Call ||
+			j.Call <= 0 && !m.time.Before(j.At) {
+			d += j.Offset
+		}
+	}
+	return d
+}
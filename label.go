@@ -0,0 +1,105 @@
+package clock
+
+import (
+	"strconv"
+	"time"
+)
+
+// labeler is implemented by Clock supporting Labeled().
+type labeler interface {
+	withLabel(label string) Clock
+}
+
+// Labeled returns Clock c whose Timer and Ticker are labeled, so the Mock
+// scripts them by LabelScripts[label] instead of their creation order and
+// names them by the label in Calls(), e.g. "retry-1.reset 1s".
+// Each label is numbered on its own, and the labeled Timer/Ticker doesn't
+// take any TimerScripts/TickerScripts number. Other Clock ignores the label.
+//
+//	c = clock.Labeled(c, "retry")
+//	t := c.NewTimer(time.Second) // "timer retry 1s"
+func Labeled(c Clock, label string) Clock {
+	if l, ok := c.(labeler); ok && label != "" {
+		return l.withLabel(label)
+	}
+	return c
+}
+
+// Label returns the handle of n-th Timer/Ticker labeled with label as in
+// Calls(), e.g. "retry", 2 for "retry-2".
+func (m *Mock) Label(label string, n int) *Handle {
+	m.sLock.Lock()
+	defer m.sLock.Unlock()
+
+	c := m.findLabeled(label, n)
+	if c == nil {
+		panic("clock.Mock has no " + label + "-" + strconv.Itoa(n))
+	}
+	return &Handle{c}
+}
+
+// findLabeled must be called with m.sLock held.
+func (m *Mock) findLabeled(label string, n int) *common {
+	for _, c := range m.labeled {
+		if c.label == label && c.no == n {
+			return c
+		}
+	}
+	return nil
+}
+
+func (m *Mock) withLabel(label string) Clock {
+	return &labeledMock{Mock: m, label: label}
+}
+
+// ===========================================================================
+
+type labeledMock struct {
+	*Mock
+	label string
+}
+
+func (m *labeledMock) NewTimer(d time.Duration) *Timer {
+	if !m.hasStarted() {
+		panic("clock.Mock must be Start() first")
+	}
+
+	t := m.newTimer("timer", m.label, d, nil)
+	return &Timer{
+		Timerable: t,
+		C:         t.tc.ch,
+	}
+}
+
+func (m *labeledMock) NewTicker(d time.Duration) *Ticker {
+	if !m.hasStarted() {
+		panic("clock.Mock must be Start() first")
+	}
+
+	t := m.newTicker(m.label, d)
+	return &Ticker{
+		Tickerable: t,
+		C:          t.tc.ch,
+	}
+}
+
+func (m *labeledMock) After(d time.Duration) <-chan time.Time {
+	if !m.hasStarted() {
+		panic("clock.Mock must be Start() first")
+	}
+
+	return m.newTimer("after", m.label, d, nil).tc.ch
+}
+
+func (m *labeledMock) AfterFunc(d time.Duration, f func()) *Timer {
+	if !m.hasStarted() {
+		panic("clock.Mock must be Start() first")
+	}
+
+	return &Timer{Timerable: m.newTimer("afterfunc", m.label, d, f)}
+}
+
+// withLabel relabels the underlying Mock.
+func (m *labeledMock) withLabel(label string) Clock {
+	return m.Mock.withLabel(label)
+}
@@ -1,6 +1,8 @@
 package clock
 
 import (
+	"context"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -8,14 +10,34 @@ import (
 
 // A Mock represents simple mock of clock.
 //
+// When Strict field is set, every Now(), Timer and Ticker call must be
+// scripted and every script must be used by Stop(). The call beyond the
+// scripts is reported with its call number and location, zero script is
+// fine to use the default.
+//
 // The clock operation can be directed by various *Scripts fields and Default
 // field. All fields are optional, the clock can run fine without any script
 // and will use DefaultScriptNow and DefaultScriptRatio on zero Default field.
-// All clock operation result can be tested using Calls() and Times() method.
+// All clock operation result can be tested using Calls() and Times() method,
+// or checked as they happen using Expect() method.
 //
 // This clock runs on fake Timer/Ticker speed divided by ratio so unit testing
 // don't have to wait a long time. The default speed ratio can be adjusted on
 // Default.Ratio field, or it can be scripted in TimerScripts/TickerScripts.
+//
+// When Virtual field is set, no real timer is used at all. The Timer/Ticker
+// only fire when the time is moved by Advance() or Set().
+//
+// The wall clock can be stepped backward or forward by Jumps field, like NTP
+// correction. Every time seen by the caller is the wall clock, while the
+// Timer/Ticker keep running on monotonic time like the real runtime.
+// When Monotonic field is set, the time also carries the monotonic reading
+// since Start() so Sub(), Since() and Until() are immune to the jumps.
+//
+// The Timer/Ticker channel follows Go 1.23 semantics: it's unbuffered,
+// Reset() and Stop() drop the unreceived value, so no stale value is
+// received afterward, and Timer Reset()/Stop() report true when the value is
+// dropped. Set AsyncTimerChan field for the buffered channel before Go 1.23.
 type Mock struct {
 	// How much duration clock.Now() will advance.
 	NowScripts []time.Duration
@@ -23,35 +45,76 @@ type Mock struct {
 	TimerScripts [][]Script
 	// The scripts for clock.Ticker.
 	TickerScripts [][]Script
+	// The scripts for labeled Timer/Ticker keyed by label, see Labeled().
+	LabelScripts map[string][][]Script
+	// The wall clock steps.
+	Jumps []Jump
+	// Add monotonic reading to the time like time.Now(). Start() fails when
+	// MonotonicSupported() is false, and it's ignored if the failure doesn't
+	// panic.
+	Monotonic bool
 	// The default setting for scripts.
 	Default Script
-
-	calls   []string
-	nows    []time.Time
-	timers  []*mockTimer
-	tickers []*mockTicker
-	sLock   sync.Mutex
-	tLock   sync.Mutex
-	cLock   sync.Mutex
-	time    time.Time
-	iNow    int
-	state   state
+	// Run Timer/Ticker on virtual time only, Script.Ratio is ignored.
+	Virtual bool
+	// Emulate Timer/Ticker channel before Go 1.23 (asynctimerchan=1).
+	AsyncTimerChan bool
+	// Report unscripted call and unused script as failure to T.
+	Strict bool
+	// Where the failure is reported, it panics when nil.
+	T ErrorReporter
+
+	events   []Event
+	eMonos   []time.Time // monotonic time of every event
+	expecter *expecter
+	nows     []time.Time
+	monos    []time.Duration
+	timers   []*mockTimer
+	tickers  []*mockTicker
+	sleeps   []*mockTimer
+	labeled  []*common
+	nLabel   map[string]int
+	nSleep   int
+	sLock    sync.Mutex
+	tLock    sync.Mutex
+	cLock    sync.Mutex
+	funcs    funcGroup
+	wLock    sync.Mutex
+	wake     chan struct{}
+	pending  int
+	time     time.Time
+	start    time.Time
+	iNow     int
+	state    state
 }
 
 // Start mocking clock and setting time to t.
 func (m *Mock) Start(t time.Time) {
+	if m.Monotonic && !monoSupported {
+		m.fail("Monotonic is not supported on this runtime")
+	}
+
 	m.sLock.Lock()
 	m.state = stateStarted
 	m.sLock.Unlock()
 
 	m.tLock.Lock()
 	m.time = t
+	m.start = t
 	m.tLock.Unlock()
 }
 
 // Stop mocking clock.
 func (m *Mock) Stop() {
+	if m.hasStarted() {
+		m.checkMissing()
+	}
+
+	var unused []string
 	m.sLock.Lock()
+	if m.Strict && m.state.IsStarted() {
+		unused = m.checkUnused()
+	}
 	if len(m.timers) > 0 {
 		for _, t := range m.timers {
 			t.stopFake()
@@ -64,12 +127,155 @@ func (m *Mock) Stop() {
 		}
 		m.tickers = m.tickers[:0]
 	}
+	if len(m.sleeps) > 0 {
+		for _, t := range m.sleeps {
+			t.stopFake()
+		}
+		m.sleeps = m.sleeps[:0]
+	}
+	if len(m.labeled) > 0 {
+		for _, t := range m.labeled {
+			t.stopFake()
+		}
+		m.labeled = m.labeled[:0]
+	}
 
 	m.state = stateStopped
 	m.sLock.Unlock()
+
+	for _, msg := range unused {
+		m.fail("%s", msg)
+	}
+}
+
+// Wait blocks until every AfterFunc callback that has been fired returns.
+// On Virtual clock, calling this after Advance() or Set() make sure every
+// callback due has done.
+func (m *Mock) Wait() {
+	m.funcs.wait()
+}
+
+// BlockUntil blocks until at least n Timer, Ticker or Sleep are pending on
+// the clock, see WaitForWaiters().
+func (m *Mock) BlockUntil(n int) {
+	m.WaitForWaiters(context.Background(), n)
+}
+
+// WaitForWaiters blocks until at least n Timer, Ticker or Sleep are pending
+// on the clock or ctx is done.
+// A Timer is pending until it fires or stopped, and a Ticker is pending until
+// it's stopped. This is useful to wait for a goroutine to arm its timer
+// before moving the time.
+func (m *Mock) WaitForWaiters(ctx context.Context, n int) error {
+	for {
+		m.wLock.Lock()
+		if m.pending >= n {
+			m.wLock.Unlock()
+			return nil
+		}
+		if m.wake == nil {
+			m.wake = make(chan struct{})
+		}
+		wake := m.wake
+		m.wLock.Unlock()
+
+		select {
+		case <-wake:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Advance moves the virtual time by d, see Set().
+func (m *Mock) Advance(d time.Duration) {
+	if !m.hasStarted() {
+		panic("clock.Mock must be Start() first")
+	}
+
+	m.tLock.Lock()
+	t := m.time.Add(d)
+	m.tLock.Unlock()
+	m.set(t)
+}
+
+// Set moves the virtual time to t and synchronously fires every virtual
+// Timer/Ticker whose deadline is not after t, in deadline order. Like the
+// real one, a Ticker fires once and drops the missed ticks.
+// The time never moves backward, t before current time only fires the
+// overdue Timer/Ticker. The t is wall clock as Current(), it's converted to
+// monotonic time by its monotonic reading or else by the current jumps.
+//
+// Please note the time moved by Now() or the scripts never fires anything
+// until the next Advance() or Set().
+func (m *Mock) Set(t time.Time) {
+	if !m.hasStarted() {
+		panic("clock.Mock must be Start() first")
+	}
+
+	m.tLock.Lock()
+	if !m.Monotonic || !monoSupported {
+		t = t.Add(-m.skew())
+	} else if mono, ok := getMono(t); ok {
+		t = m.start.Add(time.Duration(mono))
+	} else {
+		t = t.Add(-m.skew())
+	}
+	m.tLock.Unlock()
+	m.set(t)
+}
+
+// set moves the monotonic time to t, see Set().
+func (m *Mock) set(t time.Time) {
+	m.tLock.Lock()
+	if m.time.After(t) {
+		t = m.time
+	}
+	m.tLock.Unlock()
+	for {
+		c, when := m.nextDue(t)
+		if c == nil {
+			break
+		}
+		c.fire(when, t)
+	}
+
+	m.tLock.Lock()
+	if t.After(m.time) {
+		m.time = t
+	}
+	m.tLock.Unlock()
+}
+
+func (m *Mock) nextDue(t time.Time) (virtual, time.Time) {
+	m.sLock.Lock()
+	defer m.sLock.Unlock()
+
+	var v virtual
+	var when time.Time
+	check := func(c virtual) {
+		if w, ok := c.deadline(); ok && !w.After(t) &&
+			(v == nil || w.Before(when)) {
+			v, when = c, w
+		}
+	}
+	for _, c := range m.timers {
+		check(c)
+	}
+	for _, c := range m.tickers {
+		check(c)
+	}
+	for _, c := range m.sleeps {
+		check(c)
+	}
+	for _, c := range m.labeled {
+		check(c)
+	}
+	return v, when
 }
 
 // Returns list of method call.
+// This is the string of Events() without the KindFire.
 func (m *Mock) Calls() []string {
 	if !m.hasStopped() {
 		panic("clock.Mock must be Stop() first")
@@ -78,12 +284,25 @@ func (m *Mock) Calls() []string {
 	m.cLock.Lock()
 	defer m.cLock.Unlock()
 
-	return m.calls
+	return callsOf(m.events)
+}
+
+// Returns list of event.
+func (m *Mock) Events() []Event {
+	if !m.hasStopped() {
+		panic("clock.Mock must be Stop() first")
+	}
+
+	m.cLock.Lock()
+	defer m.cLock.Unlock()
+
+	return m.events
 }
 
 // Returns list of time.
 // This is the result of [clock.Now], Ticker/Timer New or Reset and
-// their channel value.
+// their channel value. The time is the wall clock, with the monotonic
+// reading since Start() when Monotonic field is set, see MonoTimes().
 func (m *Mock) Times() []time.Time {
 	if !m.hasStopped() {
 		panic("clock.Mock must be Stop() first")
@@ -95,6 +314,115 @@ func (m *Mock) Times() []time.Time {
 	return m.nows
 }
 
+// MonoTimes returns the monotonic time since Start() of every Times() entry,
+// which is not affected by Jumps field nor Monotonic field.
+func (m *Mock) MonoTimes() []time.Duration {
+	if !m.hasStopped() {
+		panic("clock.Mock must be Stop() first")
+	}
+
+	m.tLock.Lock()
+	defer m.tLock.Unlock()
+
+	return m.monos
+}
+
+// SnapshotCalls returns a copy of Calls() so far, it's safe to call at any
+// time.
+func (m *Mock) SnapshotCalls() []string {
+	m.cLock.Lock()
+	defer m.cLock.Unlock()
+
+	return callsOf(m.events)
+}
+
+// SnapshotEvents returns a copy of Events() so far, it's safe to call at
+// any time.
+func (m *Mock) SnapshotEvents() []Event {
+	m.cLock.Lock()
+	defer m.cLock.Unlock()
+
+	return slices.Clone(m.events)
+}
+
+// SnapshotTimes returns a copy of Times() so far, it's safe to call at any
+// time.
+func (m *Mock) SnapshotTimes() []time.Time {
+	m.tLock.Lock()
+	defer m.tLock.Unlock()
+
+	return slices.Clone(m.nows)
+}
+
+// Mark returns the current position of the event log, to be used by
+// CallsSince() and EventsSince(). The position counts the fire events as
+// well as the calls, see Events().
+func (m *Mock) Mark() int {
+	m.cLock.Lock()
+	defer m.cLock.Unlock()
+
+	return len(m.events)
+}
+
+// CallsSince returns the calls after the mark returned by Mark().
+func (m *Mock) CallsSince(mark int) []string {
+	m.cLock.Lock()
+	defer m.cLock.Unlock()
+
+	return callsOf(m.events[min(max(mark, 0), len(m.events)):])
+}
+
+// EventsSince returns a copy of the events after the mark returned by
+// Mark().
+func (m *Mock) EventsSince(mark int) []Event {
+	m.cLock.Lock()
+	defer m.cLock.Unlock()
+
+	return slices.Clone(m.events[min(max(mark, 0), len(m.events)):])
+}
+
+func callsOf(events []Event) []string {
+	calls := make([]string, 0, len(events))
+	for _, e := range events {
+		if e.Kind != KindFire {
+			calls = append(calls, e.String())
+		}
+	}
+	return calls
+}
+
+// Outstanding returns every active Timer and Ticker with its creation call
+// stack. A Timer is active until it fires or stopped, a Ticker is active
+// until it's stopped. It's always empty after Stop().
+func (m *Mock) Outstanding() []Live {
+	m.sLock.Lock()
+	defer m.sLock.Unlock()
+
+	var l []Live
+	for _, t := range m.timers {
+		if _, ok := t.Deadline(); ok {
+			l = append(l, liveOf(t.id(), t.stack))
+		}
+	}
+	for _, t := range m.tickers {
+		if _, ok := t.Deadline(); ok {
+			l = append(l, liveOf(t.id(), t.stack))
+		}
+	}
+	for _, t := range m.labeled {
+		if _, ok := t.Deadline(); ok {
+			l = append(l, liveOf(t.id(), t.stack))
+		}
+	}
+	return l
+}
+
+// Current returns the current mocked time without advancing it.
+// Unlike Now(), this is not a call so it's not in Calls().
+func (m *Mock) Current() time.Time {
+	return m.wall(m.now())
+}
+
 // Now returns the current mocked time.
 // Please note this always advance the time.
 func (m *Mock) Now() time.Time {
@@ -102,14 +430,23 @@ func (m *Mock) Now() time.Time {
 		panic("clock.Mock must be Start() first")
 	}
 
-	m.addCall("now")
-	return m.incTime(m.incNow())
+	mono, t := m.incTime(m.incNow("now"))
+	m.addEvent(Event{Kind: KindNow, Name: "now", Time: t}, mono)
+	return t
 }
 
-func (m *Mock) incNow() time.Duration {
-	if m.iNow++; m.iNow <= len(m.NowScripts) && m.NowScripts[m.iNow-1] > 0 {
-		return m.NowScripts[m.iNow-1]
+func (m *Mock) incNow(name string) time.Duration {
+	m.tLock.Lock()
+	m.iNow++
+	i := m.iNow
+	m.tLock.Unlock()
+
+	if i <= len(m.NowScripts) && m.NowScripts[i-1] > 0 {
+		return m.NowScripts[i-1]
 	} else {
+		if i > len(m.NowScripts) {
+			m.unscripted(name, i)
+		}
 		return m.Default.canon().Now
 	}
 }
@@ -128,28 +465,81 @@ func (m *Mock) hasStarted() bool {
 	return m.state.IsStarted()
 }
 
-func (m *Mock) incTime(d time.Duration) time.Time {
+// incTime advances the time by d and returns the monotonic and wall time.
+func (m *Mock) incTime(d time.Duration) (time.Time, time.Time) {
 	m.tLock.Lock()
 	defer m.tLock.Unlock()
 
 	m.time = m.time.Add(d)
-	m.nows = append(m.nows, m.time)
-	return m.time
+	wall := m.wallOf(m.time)
+	m.nows = append(m.nows, wall)
+	m.monos = append(m.monos, m.time.Sub(m.start))
+	return m.time, wall
 }
 
 func (m *Mock) incTimeTo(t time.Time) {
 	m.tLock.Lock()
 	if t.After(m.time) {
 		m.time = t
-		m.nows = append(m.nows, m.time)
+		m.nows = append(m.nows, m.wallOf(m.time))
+		m.monos = append(m.monos, m.time.Sub(m.start))
 	}
 	m.tLock.Unlock()
 }
 
-func (m *Mock) addCall(call string) {
+// wall returns the wall clock of monotonic time t by the current jumps.
+func (m *Mock) wall(t time.Time) time.Time {
+	m.tLock.Lock()
+	defer m.tLock.Unlock()
+
+	return m.wallOf(t)
+}
+
+// wallOf must be called with m.tLock held, see wall().
+func (m *Mock) wallOf(t time.Time) time.Time {
+	w := t.Add(m.skew())
+	if m.Monotonic && monoSupported {
+		w = setMono(w, int64(t.Sub(m.start)))
+	}
+	return w
+}
+
+func (m *Mock) addPending(on bool) {
+	m.wLock.Lock()
+	if on {
+		m.pending++
+	} else {
+		m.pending--
+	}
+	if m.wake != nil {
+		close(m.wake)
+		m.wake = nil
+	}
+	m.wLock.Unlock()
+}
+
+// addEvent logs e that happens at the monotonic time mono.
+func (m *Mock) addEvent(e Event, mono time.Time) {
+	e.Real = time.Now()
+	var msg string
 	m.cLock.Lock()
-	m.calls = append(m.calls, call)
+	m.events = append(m.events, e)
+	m.eMonos = append(m.eMonos, mono)
+	if m.expecter != nil && e.Kind != KindFire {
+		msg = m.expecter.match(e.String())
+	}
 	m.cLock.Unlock()
+
+	if msg != "" {
+		m.fail("%s", msg)
+	}
+}
+
+func (m *Mock) now() time.Time {
+	m.tLock.Lock()
+	defer m.tLock.Unlock()
+
+	return m.time
 }
 
 // NewTimer returns a new [time.Timer] compatible Timer.
@@ -158,20 +548,10 @@ func (m *Mock) NewTimer(d time.Duration) *Timer {
 		panic("clock.Mock must be Start() first")
 	}
 
-	m.addCall("timer " + d.String())
-	t := new(mockTimer)
-	m.timers = append(m.timers, t)
-	t.init(m, len(m.timers))
-	s := getScript(m.TimerScripts, t.no, &t.i, m.Default)
-	t.update(s)
-	t.fake = time.NewTimer(d / s.Ratio)
-	t.setNow()
-	ch := make(chan time.Time, 1)
-	go t.run(ch, t.fake.C)
-
+	t := m.newTimer("timer", "", d, nil)
 	return &Timer{
 		Timerable: t,
-		C:         ch,
+		C:         t.tc.ch,
 	}
 }
 
@@ -181,128 +561,436 @@ func (m *Mock) NewTicker(d time.Duration) *Ticker {
 		panic("clock.Mock must be Start() first")
 	}
 
-	m.addCall("ticker " + d.String())
-	t := new(mockTicker)
-	m.tickers = append(m.tickers, t)
-	t.init(m, len(m.tickers))
-	s := getScript(m.TickerScripts, t.no, &t.i, m.Default)
-	t.update(s)
-	t.fake = time.NewTicker(d / s.Ratio)
-	t.setNow()
-	ch := make(chan time.Time, 1)
-	go t.run(ch, t.fake.C)
-
+	t := m.newTicker("", d)
 	return &Ticker{
 		Tickerable: t,
-		C:          ch,
+		C:          t.tc.ch,
+	}
+}
+
+// Sleep pauses the current goroutine for at least the duration d.
+// A zero or negative duration causes Sleep to return immediately.
+// The sleep uses Default script and is not counted as Timer.
+func (m *Mock) Sleep(d time.Duration) {
+	if !m.hasStarted() {
+		panic("clock.Mock must be Start() first")
+	}
+
+	t := new(mockTimer)
+	t.name = "sleep"
+	m.sLock.Lock()
+	m.nSleep++
+	t.init(m, m.nSleep)
+	if d > 0 {
+		m.sleeps = append(m.sleeps, t)
+	}
+	m.sLock.Unlock()
+	if d <= 0 {
+		t.time, _ = m.incTime(m.Default.canon().Now)
+		t.addEvent(KindNew, d, false)
+		return
+	}
+	t.reset(m.Default.canon(), d, 0)
+	t.addEvent(KindNew, d, false)
+	select {
+	case <-t.tc.ch:
+	case <-t.stop:
+	}
+
+	m.sLock.Lock()
+	if i := slices.Index(m.sleeps, t); i >= 0 {
+		m.sleeps = slices.Delete(m.sleeps, i, i+1)
+	}
+	m.sLock.Unlock()
+}
+
+// After waits for the duration to elapse and then sends the current time on
+// the returned channel.
+// It is counted as Timer so it uses TimerScripts.
+func (m *Mock) After(d time.Duration) <-chan time.Time {
+	if !m.hasStarted() {
+		panic("clock.Mock must be Start() first")
 	}
+
+	return m.newTimer("after", "", d, nil).tc.ch
+}
+
+// AfterFunc waits for the duration to elapse and then calls f in its own
+// goroutine. It returns a Timer with nil C which Stop() and Reset() follow
+// [time.AfterFunc] semantics, the fired callback can be waited by Wait().
+// It is counted as Timer so it uses TimerScripts.
+func (m *Mock) AfterFunc(d time.Duration, f func()) *Timer {
+	if !m.hasStarted() {
+		panic("clock.Mock must be Start() first")
+	}
+
+	return &Timer{Timerable: m.newTimer("afterfunc", "", d, f)}
+}
+
+// Since returns the time elapsed since t.
+// Please note this always advance the time like Now().
+func (m *Mock) Since(t time.Time) time.Duration {
+	if !m.hasStarted() {
+		panic("clock.Mock must be Start() first")
+	}
+
+	mono, now := m.incTime(m.incNow("since"))
+	m.addEvent(Event{Kind: KindNow, Name: "since", Time: now}, mono)
+	return now.Sub(t)
+}
+
+// Until returns the duration until t.
+// Please note this always advance the time like Now().
+func (m *Mock) Until(t time.Time) time.Duration {
+	if !m.hasStarted() {
+		panic("clock.Mock must be Start() first")
+	}
+
+	mono, now := m.incTime(m.incNow("until"))
+	m.addEvent(Event{Kind: KindNow, Name: "until", Time: now}, mono)
+	return t.Sub(now)
+}
+
+func (m *Mock) newTimer(name, label string, d time.Duration,
+	f func(),
+) *mockTimer {
+	t := new(mockTimer)
+	t.name = name
+	t.label = label
+	t.f = f
+	t.stack = callers()
+	m.sLock.Lock()
+	if label == "" {
+		m.timers = append(m.timers, t)
+		t.init(m, len(m.timers))
+	} else {
+		t.init(m, m.addLabeled(&t.common))
+	}
+	m.sLock.Unlock()
+	t.reset(t.script(m.TimerScripts), d, 0)
+	t.addEvent(KindNew, d, false)
+	return t
+}
+
+func (m *Mock) newTicker(label string, d time.Duration) *mockTicker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	t := new(mockTicker)
+	t.name = "ticker"
+	t.label = label
+	t.stack = callers()
+	m.sLock.Lock()
+	if label == "" {
+		m.tickers = append(m.tickers, t)
+		t.init(m, len(m.tickers))
+	} else {
+		t.init(m, m.addLabeled(&t.common))
+	}
+	m.sLock.Unlock()
+	t.reset(t.script(m.TickerScripts), d, d)
+	t.addEvent(KindNew, d, false)
+	return t
+}
+
+// addLabeled must be called with m.sLock held, it returns the number of c
+// within its label.
+func (m *Mock) addLabeled(c *common) int {
+	if m.nLabel == nil {
+		m.nLabel = make(map[string]int)
+	}
+	m.labeled = append(m.labeled, c)
+	m.nLabel[c.label]++
+	return m.nLabel[c.label]
 }
 
 // ===========================================================================
 
+// virtual is a Timer/Ticker running on virtual time.
+type virtual interface {
+	deadline() (time.Time, bool)
+	fire(when, t time.Time)
+}
+
 type common struct {
-	stop  chan struct{}
-	time  time.Time
-	rtime time.Time
-	ratio time.Duration
-	lock  sync.Mutex
-	mock  *Mock
-	no    int
-	i     int
+	stop    chan struct{}
+	time    time.Time
+	rtime   time.Time
+	ratio   time.Duration
+	lock    sync.Mutex
+	mock    *Mock
+	name    string
+	label   string
+	no      int
+	i       int
+	tc      timerChan
+	f       func()
+	gen     int
+	d       time.Duration
+	period  time.Duration
+	fires   int
+	active  bool
+	virtual bool
+	when    time.Time   // virtual only
+	fake    *time.Timer // real only
+	stack   []uintptr
+	rnext   time.Time // real only
 }
 
 func (c *common) init(mock *Mock, no int) {
 	c.mock = mock
 	c.no = no
+	c.virtual = mock.Virtual
 	c.stop = make(chan struct{})
+	c.tc = newTimerChan(mock.AsyncTimerChan, mock.AsyncTimerChan)
+}
+
+func (c *common) addEvent(k Kind, d time.Duration, ret bool) {
+	var t time.Time
+	if k == KindStop {
+		t = c.mock.now()
+	} else {
+		c.lock.Lock()
+		t = c.time
+		c.lock.Unlock()
+	}
+	c.mock.addEvent(Event{
+		Kind:     k,
+		Name:     c.name,
+		Label:    c.label,
+		No:       c.no,
+		Duration: d,
+		Time:     c.mock.wall(t),
+		Ret:      ret,
+	}, t)
 }
 
-func (c *common) update(s Script) {
+// addFire logs the fire at the monotonic time t.
+func (c *common) addFire(t time.Time) {
+	c.mock.addEvent(Event{
+		Kind:  KindFire,
+		Name:  c.name,
+		Label: c.label,
+		No:    c.no,
+		Time:  c.mock.wall(t),
+	}, t)
+}
+
+// id returns the name as in Calls(), e.g. "timer-2" or "retry-1".
+func (c *common) id() string {
+	if c.label != "" {
+		return c.label + "-" + strconv.Itoa(c.no)
+	}
+	return c.name + "-" + strconv.Itoa(c.no)
+}
+
+// script returns the next script from l, or from LabelScripts when it's
+// labeled.
+func (c *common) script(l [][]Script) Script {
+	if c.label != "" {
+		l = c.mock.LabelScripts[c.label]
+	}
+	s, ok := getScript(l, c.no, &c.i, c.mock.Default)
+	if !ok {
+		c.mock.unscripted(c.id(), c.i)
+	}
+	return s
+}
+
+// reset applies script s and arms the Timer/Ticker to fire after d, see
+// arm().
+func (c *common) reset(s Script, d, period time.Duration) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	c.ratio = s.Ratio
-	c.time = c.mock.incTime(s.Now)
+	c.time, _ = c.mock.incTime(s.Now)
+	c.d = d
+	c.period = period
+	return c.arm(d)
+}
+
+// setActive must be called with c.lock held, it returns previous state.
+func (c *common) setActive(on bool) bool {
+	ret := c.active
+	if ret != on {
+		c.active = on
+		c.mock.addPending(on)
+	}
+	return ret
+}
+
+// arm must be called with c.lock held, it returns the same as disarm().
+func (c *common) arm(d time.Duration) bool {
+	ret := c.disarm()
+	c.setActive(true)
+	if c.virtual {
+		c.when = c.time.Add(d)
+	} else {
+		c.rtime = time.Now()
+		c.rnext = c.rtime.Add(d / c.ratio)
+		c.schedule()
+	}
+	return ret
+}
+
+// disarm must be called with c.lock held, it returns whether it was active
+// or the unreceived value was dropped, see timerChan.
+func (c *common) disarm() bool {
+	c.gen++
+	if c.fake != nil {
+		c.fake.Stop()
+	}
+	ret := c.setActive(false)
+	if c.tc.drain() {
+		ret = true
+	}
+	return ret
+}
+
+// schedule must be called with c.lock held.
+func (c *common) schedule() {
+	gen := c.gen
+	c.fake = time.AfterFunc(time.Until(c.rnext), func() { c.expire(gen) })
 }
 
-func (c *common) setNow() {
+// expire runs on fake timer goroutine, it does nothing if the fake timer has
+// been disarmed since.
+func (c *common) expire(gen int) {
 	c.lock.Lock()
-	c.rtime = time.Now()
-	c.lock.Unlock()
+	defer c.lock.Unlock()
+
+	if gen != c.gen {
+		return
+	}
+	// use the scheduled time instead of time.Now() to avoid jitter
+	c.time = c.time.Add(c.rnext.Sub(c.rtime) * c.ratio)
+	c.rtime = c.rnext
+	if c.period > 0 {
+		rp := max(c.period/c.ratio, 1)
+		c.rnext = nextTick(c.rnext, time.Now(), rp)
+		c.schedule()
+	} else {
+		c.setActive(false)
+	}
+	c.deliver(c.time)
+}
+
+// Mock returns the Mock that creates the Timer/Ticker.
+func (c *common) Mock() *Mock {
+	return c.mock
 }
 
-func (c *common) addTime(t time.Time) time.Time {
+// Deadline returns the mocked wall time the Timer/Ticker will fire next and
+// whether it's active.
+func (c *common) Deadline() (time.Time, bool) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	c.time = c.time.Add(t.Sub(c.rtime) * c.ratio)
-	c.rtime = t
-	return c.time
+	if !c.active {
+		return time.Time{}, false
+	}
+	if c.virtual {
+		return c.mock.wall(c.when), true
+	}
+	return c.mock.wall(c.time.Add(c.rnext.Sub(c.rtime) * c.ratio)), true
 }
 
-func (c *common) run(dst chan<- time.Time, src <-chan time.Time) {
-	for {
-		select {
-		case <-c.stop:
-			return
-		case t := <-src:
-			nt := c.addTime(t)
-			c.mock.incTimeTo(nt)
-			if len(dst) == 0 {
-				dst <- nt
-			}
-		}
+// Unreceived reports whether the fired value has not been received yet.
+func (c *common) Unreceived() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.tc.unreceived()
+}
+
+func (c *common) deadline() (time.Time, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.when, c.active && c.virtual
+}
+
+// fire sends the virtual time when to the channel, unless the deadline has
+// been changed since. The Ticker drops the missed ticks up to t, the time
+// being set.
+func (c *common) fire(when, t time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.active || !c.when.Equal(when) {
+		return
+	}
+	if c.period > 0 {
+		c.when = nextTick(when, t, c.period)
+	} else {
+		c.setActive(false)
+	}
+	c.deliver(when)
+}
+
+// deliver must be called with c.lock held, t is the monotonic time and the
+// channel receives the wall time.
+func (c *common) deliver(t time.Time) {
+	c.fires++
+	c.mock.incTimeTo(t)
+	c.addFire(t)
+	if c.f != nil {
+		c.mock.funcs.run(c.f)
+	} else {
+		c.tc.send(c.mock.wall(t))
 	}
 }
 
+func (c *common) stopFake() {
+	c.lock.Lock()
+	c.gen++
+	if c.fake != nil {
+		c.fake.Stop()
+	}
+	c.setActive(false)
+	c.tc.drain()
+	close(c.stop)
+	c.lock.Unlock()
+}
+
 // ===========================================================================
 
 type mockTimer struct {
 	common
-	fake *time.Timer
 }
 
 func (t *mockTimer) Stop() bool {
-	t.mock.addCall("timer-" + strconv.Itoa(t.no) + ".stop")
-	return t.fake.Stop()
+	t.lock.Lock()
+	ret := t.disarm()
+	t.lock.Unlock()
+	t.addEvent(KindStop, 0, ret)
+	return ret
 }
 
 func (t *mockTimer) Reset(d time.Duration) bool {
-	s := getScript(t.mock.TimerScripts, t.no, &t.i, t.mock.Default)
-	t.update(s)
-	ret := t.fake.Reset(d / s.Ratio)
-	t.setNow()
-	t.mock.addCall("timer-" + strconv.Itoa(t.no) + ".reset " + d.String())
+	ret := t.reset(t.script(t.mock.TimerScripts), d, 0)
+	t.addEvent(KindReset, d, ret)
 	return ret
 }
 
-func (t *mockTimer) stopFake() {
-	t.lock.Lock()
-	t.fake.Stop()
-	close(t.stop)
-	t.lock.Unlock()
-}
-
 // ===========================================================================
 
 type mockTicker struct {
 	common
-	fake *time.Ticker
 }
 
 func (t *mockTicker) Stop() {
-	t.fake.Stop()
-	t.mock.addCall("ticker-" + strconv.Itoa(t.no) + ".stop")
+	t.lock.Lock()
+	t.disarm()
+	t.lock.Unlock()
+	t.addEvent(KindStop, 0, false)
 }
 
 func (t *mockTicker) Reset(d time.Duration) {
-	s := getScript(t.mock.TickerScripts, t.no, &t.i, t.mock.Default)
-	t.update(s)
-	t.fake.Reset(d / s.Ratio)
-	t.setNow()
-	t.mock.addCall("ticker-" + strconv.Itoa(t.no) + ".reset " + d.String())
-}
-
-func (t *mockTicker) stopFake() {
-	t.lock.Lock()
-	t.fake.Stop()
-	close(t.stop)
-	t.lock.Unlock()
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+	t.reset(t.script(t.mock.TickerScripts), d, d)
+	t.addEvent(KindReset, d, false)
 }
@@ -1,6 +1,8 @@
 package clock_test
 
 import (
+	"context"
+	"sync/atomic"
 	"time"
 
 	. "github.com/bangzek/clock"
@@ -261,6 +263,766 @@ var _ = Describe("Mock", func() {
 		})
 	})
 
+	Describe("Sleep", func() {
+		It("sleeps and appends sleep to calls", func() {
+			const d = 200 * ms
+			c.Start(tm)
+			t1 := time.Now()
+			c.Sleep(d)
+			Expect(time.Since(t1)).To(BeNumerically(">=", d/10))
+			c.Stop()
+			Expect(c.Calls()).To(Equal([]string{"sleep 200ms"}))
+			Expect(c.Times()).To(Equal([]time.Time{
+				tm.Add(DefaultScriptNow),
+				tm.Add(DefaultScriptNow + d),
+			}))
+		})
+	})
+
+	Describe("After, AfterFunc, Since and Until", func() {
+		It("append them to calls", func() {
+			c.Start(tm)
+			ch := c.After(time.Second)
+			done := make(chan struct{})
+			t := c.AfterFunc(2*time.Second, func() { close(done) })
+			Expect(t.C).To(BeNil())
+			Eventually(ch).Should(Receive())
+			Eventually(done).Should(BeClosed())
+			Expect(t.Reset(time.Second)).To(BeFalse())
+			Expect(t.Stop()).To(BeTrue())
+			const dn = DefaultScriptNow
+			Expect(c.Since(tm)).To(BeNumerically(">", 2*time.Second+3*dn))
+			Expect(c.Until(tm.Add(time.Hour))).
+				To(BeNumerically("<", time.Hour-2*time.Second-4*dn))
+			c.Stop()
+			Expect(c.Calls()).To(Equal([]string{
+				"after 1s",
+				"afterfunc 2s",
+				"afterfunc-2.reset 1s",
+				"afterfunc-2.stop",
+				"since",
+				"until",
+			}))
+		})
+	})
+
+	Describe("AfterFunc", func() {
+		Context("scripted", func() {
+			It("runs the callback on the script ratio", func() {
+				const d = 2 * ms
+				c.TimerScripts = [][]Script{
+					nil,
+					{{d, 100}},
+				}
+				c.Start(tm)
+				t1 := c.NewTimer(time.Hour)
+				var n int32
+				rt := time.Now()
+				done := make(chan time.Time)
+				c.AfterFunc(time.Second, func() {
+					atomic.AddInt32(&n, 1)
+					done <- time.Now()
+				})
+				Expect(<-done).To(BeTemporally(">=", rt.Add(10*ms)))
+				c.Wait()
+				Expect(atomic.LoadInt32(&n)).To(BeEquivalentTo(1))
+				Expect(t1.Stop()).To(BeTrue())
+				c.Stop()
+				Expect(c.Calls()).To(Equal([]string{
+					"timer 1h0m0s",
+					"afterfunc 1s",
+					"timer-1.stop",
+				}))
+				const dn = DefaultScriptNow
+				Expect(c.Times()).To(Equal([]time.Time{
+					tm.Add(dn),
+					tm.Add(dn + d),
+					tm.Add(dn + d + time.Second),
+				}))
+			})
+		})
+
+		Context("virtual", func() {
+			BeforeEach(func() { c.Virtual = true })
+
+			It("can be stopped, reset and waited", func() {
+				c.Start(tm)
+				var n int32
+				t := c.AfterFunc(time.Second, func() {
+					time.Sleep(10 * ms)
+					atomic.AddInt32(&n, 1)
+				})
+				Expect(t.C).To(BeNil())
+				Expect(t.Stop()).To(BeTrue(), "1st stop")
+				c.Advance(time.Second)
+				c.Wait()
+				Expect(atomic.LoadInt32(&n)).To(BeZero())
+
+				Expect(t.Reset(time.Second)).To(BeFalse(), "1st reset")
+				c.Advance(time.Second)
+				c.Wait()
+				Expect(atomic.LoadInt32(&n)).To(BeEquivalentTo(1))
+
+				Expect(t.Reset(time.Second)).To(BeFalse(), "2nd reset")
+				Expect(t.Reset(time.Second)).To(BeTrue(), "3rd reset")
+				c.Advance(time.Second)
+				c.Wait()
+				Expect(atomic.LoadInt32(&n)).To(BeEquivalentTo(2))
+				Expect(t.Stop()).To(BeFalse(), "2nd stop")
+				c.Stop()
+				Expect(c.Calls()).To(Equal([]string{
+					"afterfunc 1s",
+					"afterfunc-1.stop",
+					"afterfunc-1.reset 1s",
+					"afterfunc-1.reset 1s",
+					"afterfunc-1.reset 1s",
+					"afterfunc-1.stop",
+				}))
+			})
+		})
+	})
+
+	Describe("WaitForWaiters", func() {
+		It("counts pending Timer and Ticker", func() {
+			c.Start(tm)
+			ctx, cancel := context.WithTimeout(context.Background(), 20*ms)
+			defer cancel()
+			Expect(c.WaitForWaiters(ctx, 1)).
+				To(MatchError(context.DeadlineExceeded))
+
+			go func() {
+				c.NewTicker(time.Second)
+				c.NewTimer(time.Minute)
+			}()
+			c.BlockUntil(2)
+			Expect(c.WaitForWaiters(context.Background(), 2)).To(Succeed())
+
+			ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			done := make(chan error)
+			go func() { done <- c.WaitForWaiters(ctx, 3) }()
+			Consistently(done, 20*ms).ShouldNot(Receive())
+			c.NewTimer(time.Second)
+			Eventually(done).Should(Receive(BeNil()))
+			c.Stop()
+		})
+	})
+
+	Describe("Events", func() {
+		It("logs typed events", func() {
+			const dn = DefaultScriptNow
+			c.Virtual = true
+			c.Start(tm)
+			t := c.NewTimer(time.Second)
+			c.Advance(time.Second)
+			// the unreceived value is dropped
+			Expect(t.Reset(500 * ms)).To(BeTrue())
+			Expect(t.Stop()).To(BeTrue())
+			c.Now()
+			c.Stop()
+
+			ev := c.Events()
+			Expect(ev).To(HaveLen(5))
+			for i, e := range ev {
+				Expect(e.Real).NotTo(BeZero(), "real #%d", i)
+				ev[i].Real = time.Time{}
+			}
+			Expect(ev).To(Equal([]Event{
+				{
+					Kind:     KindNew,
+					Name:     "timer",
+					No:       1,
+					Duration: time.Second,
+					Time:     tm.Add(dn),
+				},
+				{
+					Kind: KindFire,
+					Name: "timer",
+					No:   1,
+					Time: tm.Add(dn + time.Second),
+				},
+				{
+					Kind:     KindReset,
+					Name:     "timer",
+					No:       1,
+					Duration: 500 * ms,
+					Time:     tm.Add(2*dn + time.Second),
+					Ret:      true,
+				},
+				{
+					Kind: KindStop,
+					Name: "timer",
+					No:   1,
+					Time: tm.Add(2*dn + time.Second),
+					Ret:  true,
+				},
+				{Kind: KindNow, Name: "now", Time: tm.Add(3*dn + time.Second)},
+			}))
+			Expect(c.Calls()).To(Equal([]string{
+				"timer 1s",
+				"timer-1.reset 500ms",
+				"timer-1.stop",
+				"now",
+			}))
+		})
+	})
+
+	Describe("Snapshot", func() {
+		It("can be called while running", func() {
+			const dn = DefaultScriptNow
+			Expect(c.SnapshotCalls()).To(BeEmpty())
+			c.Start(tm)
+			c.Now()
+			Expect(c.SnapshotCalls()).To(Equal([]string{"now"}))
+			Expect(c.SnapshotTimes()).To(Equal([]time.Time{tm.Add(dn)}))
+			Expect(c.SnapshotEvents()).To(HaveLen(1))
+
+			mark := c.Mark()
+			t := c.NewTimer(time.Second)
+			t.Stop()
+			Expect(c.CallsSince(mark)).To(Equal([]string{
+				"timer 1s",
+				"timer-1.stop",
+			}))
+			Expect(c.EventsSince(mark)).To(HaveLen(2))
+
+			mark = c.Mark()
+			Expect(c.CallsSince(mark)).To(BeEmpty())
+			c.Now()
+			Expect(c.CallsSince(mark)).To(Equal([]string{"now"}))
+			Expect(c.CallsSince(-1)).To(Equal(c.SnapshotCalls()))
+			Expect(c.EventsSince(-1)).To(HaveLen(4))
+			c.Stop()
+			Expect(c.SnapshotCalls()).To(Equal(c.Calls()))
+		})
+	})
+
+	Describe("Timer channel", func() {
+		BeforeEach(func() { c.Virtual = true })
+
+		It("drops stale value like Go 1.23", func() {
+			c.Start(tm)
+			t1 := c.NewTimer(time.Second)
+			t2 := c.NewTicker(time.Second)
+			c.Advance(time.Second)
+			Expect(cap(t1.C)).To(BeZero(), "cap")
+			Expect(len(t1.C)).To(BeZero(), "len")
+			Expect(t1.Stop()).To(BeTrue(), "stop")
+			Expect(t1.C).NotTo(Receive())
+			Expect(t1.Reset(time.Second)).To(BeFalse(), "1st reset")
+			c.Advance(time.Second)
+			Expect(t1.Reset(time.Second)).To(BeTrue(), "2nd reset")
+			Expect(t1.C).NotTo(Receive())
+			t2.Reset(time.Second)
+			Expect(t2.C).NotTo(Receive())
+			c.Stop()
+		})
+
+		It("keeps stale value before Go 1.23", func() {
+			c.AsyncTimerChan = true
+			c.Start(tm)
+			t1 := c.NewTimer(time.Second)
+			t2 := c.NewTicker(time.Second)
+			c.Advance(time.Second)
+			Expect(cap(t1.C)).To(Equal(1), "cap")
+			Expect(len(t1.C)).To(Equal(1), "len")
+			Expect(t1.Stop()).To(BeFalse(), "stop")
+			Expect(t1.C).To(Receive())
+			Expect(t1.Reset(time.Second)).To(BeFalse(), "1st reset")
+			c.Advance(time.Second)
+			Expect(t1.Reset(time.Second)).To(BeFalse(), "2nd reset")
+			Expect(t1.C).To(Receive())
+			t2.Reset(time.Second)
+			Expect(t2.C).To(Receive())
+			c.Stop()
+		})
+
+		It("drops stale value on real timer", func() {
+			c.Virtual = false
+			c.Start(tm)
+			t := c.NewTimer(10 * ms)
+			Eventually(c.SnapshotEvents).Should(ContainElement(
+				HaveField("Kind", KindFire)))
+			Expect(t.Reset(time.Second)).To(BeTrue())
+			Expect(t.C).NotTo(Receive())
+			c.Stop()
+		})
+	})
+
+	Describe("Strict", func() {
+		var t *fakeT
+		BeforeEach(func() {
+			t = new(fakeT)
+			c.Strict = true
+			c.T = t
+		})
+
+		It("reports unscripted calls", func() {
+			c.NowScripts = []time.Duration{ms, 0}
+			c.TimerScripts = [][]Script{{{}}}
+			c.Start(tm)
+			c.Now()
+			c.Now()
+			Expect(t.Errors()).To(BeEmpty())
+			c.Since(tm)
+			tr := c.NewTimer(time.Second)
+			tr.Reset(time.Second)
+			c.NewTicker(time.Second)
+			c.Stop()
+			const at = ` at .*/mock_test.go:\d+$`
+			Expect(t.Errors()).To(HaveExactElements(
+				MatchRegexp(`^clock.Mock: unscripted since call #3`+at),
+				MatchRegexp(`^clock.Mock: unscripted timer-1 call #2`+at),
+				MatchRegexp(`^clock.Mock: unscripted ticker-1 call #1`+at),
+			))
+		})
+
+		It("reports unused scripts", func() {
+			c.NowScripts = []time.Duration{ms, 0}
+			c.TimerScripts = [][]Script{{{}, {}}, {{}}}
+			c.TickerScripts = [][]Script{nil, {{}}}
+			c.Start(tm)
+			c.Now()
+			c.NewTimer(time.Second)
+			c.Stop()
+			Expect(t.Errors()).To(Equal([]string{
+				"clock.Mock: unused NowScripts[1:]",
+				"clock.Mock: unused TimerScripts[0][1:]",
+				"clock.Mock: unused TimerScripts[1][0:]",
+				"clock.Mock: unused TickerScripts[1][0:]",
+			}))
+		})
+
+		It("panics without T", func() {
+			c.T = nil
+			c.Start(tm)
+			Expect(func() { c.Now() }).To(PanicWith(
+				MatchRegexp(`^clock.Mock: unscripted now call #1 at `)))
+			c.Stop()
+		})
+
+		It("panics on unused scripts after stopped", func() {
+			c.T = nil
+			c.NowScripts = []time.Duration{ms}
+			c.Start(tm)
+			Expect(c.Stop).To(PanicWith("clock.Mock: unused NowScripts[0:]"))
+			c.Stop()
+			Expect(c.Calls()).To(BeEmpty())
+		})
+	})
+
+	Describe("Expect", func() {
+		var t *fakeT
+		BeforeEach(func() {
+			t = new(fakeT)
+			c.T = t
+			c.Virtual = true
+		})
+
+		It("passes the expected calls", func() {
+			c.Expect(
+				Call("timer 5s"),
+				AnyTimes("now"),
+				Unordered(Call("timer-1.reset 1s"), Call("ticker 1s")),
+			)
+			c.Expect(Call("timer-1.stop"))
+			c.Start(tm)
+			tr := c.NewTimer(5 * time.Second)
+			c.Now()
+			c.Now()
+			c.NewTicker(time.Second)
+			tr.Reset(time.Second)
+			c.Advance(time.Second)
+			tr.Stop()
+			c.Stop()
+			Expect(t.Errors()).To(BeEmpty())
+		})
+
+		It("reports the first deviation", func() {
+			c.Expect(
+				Call("timer 5s"),
+				AnyTimes("now"),
+				Call("timer-1.reset 1s"),
+				Call("timer-1.stop"),
+			)
+			c.Start(tm)
+			tr := c.NewTimer(5 * time.Second)
+			c.Now()
+			tr.Stop()
+			tr.Reset(time.Second)
+			c.Stop()
+			Expect(t.Errors()).To(HaveExactElements(MatchRegexp(
+				`^clock.Mock: unexpected call "timer-1.stop" at ` +
+					`.*/mock_test.go:\d+\n` +
+					`  timer 5s\n` +
+					`  now\n` +
+					`- any number of "now"\n` +
+					`\+ "timer-1.stop"$`,
+			)))
+		})
+
+		It("reports missing calls", func() {
+			c.Expect(
+				Call("timer 5s"),
+				Unordered(Call("ticker 1s"), AnyTimes("now")),
+				Call("timer-1.stop"),
+			)
+			c.Start(tm)
+			c.NewTimer(5 * time.Second)
+			c.Now()
+			c.Stop()
+			Expect(t.Errors()).To(Equal([]string{
+				"clock.Mock: missing expected calls:\n" +
+					`- in any order {"ticker 1s", any number of "now"}` +
+					"\n" + `- "timer-1.stop"`,
+			}))
+		})
+	})
+
+	Describe("Outstanding", func() {
+		It("lists the active Timer and Ticker", func() {
+			c.Virtual = true
+			c.Start(tm)
+			c.NewTimer(time.Second)
+			c.NewTicker(time.Second)
+			c.NewTimer(2 * time.Second).Stop()
+			c.NewTicker(time.Second).Stop()
+			c.Advance(time.Second)
+
+			l := c.Outstanding()
+			Expect(l).To(HaveExactElements(
+				HaveField("Name", "ticker-1"),
+			))
+			Expect(l[0].Site).To(MatchRegexp(`/mock_test.go:\d+$`))
+			Expect(l[0].Stack).To(ContainSubstring("mock_test.go"))
+			c.Stop()
+			Expect(c.Outstanding()).To(BeEmpty())
+		})
+	})
+
+	Describe("Handle", func() {
+		BeforeEach(func() { c.Virtual = true })
+
+		It("fires the Timer and Ticker manually", func() {
+			const dn = DefaultScriptNow
+			c.Start(tm)
+			t1 := c.NewTimer(time.Second)
+			t2 := c.NewTicker(300 * ms)
+			h1, h2 := c.Timer(1), c.Ticker(1)
+			Expect(h1.Duration()).To(Equal(time.Second))
+			Expect(h2.Duration()).To(Equal(300 * ms))
+			when, ok := h1.Deadline()
+			Expect(ok).To(BeTrue())
+			Expect(when).To(Equal(tm.Add(dn + time.Second)))
+
+			Expect(h1.Fire()).To(BeTrue())
+			Expect(t1.C).To(Receive(Equal(tm.Add(2 * dn))))
+			Expect(h1.Active()).To(BeFalse())
+			Expect(h1.Fire()).To(BeFalse())
+			c.Advance(time.Second)
+			Expect(t1.C).NotTo(Receive())
+			Expect(h1.FireCount()).To(Equal(1))
+
+			Expect(h2.Fire()).To(BeTrue())
+			Expect(t2.C).To(Receive())
+			Expect(h2.Active()).To(BeTrue())
+			Expect(h2.FireCount()).To(Equal(2))
+
+			t1.Reset(500 * ms)
+			Expect(h1.Duration()).To(Equal(500 * ms))
+			Expect(h1.Active()).To(BeTrue())
+			t1.Stop()
+			t2.Stop()
+			c.Stop()
+
+			Expect(c.Events()).To(ContainElement(
+				HaveField("String()", "timer-1.fire")))
+		})
+
+		It("panics for unknown Timer or Ticker", func() {
+			c.Start(tm)
+			c.NewTimer(time.Second)
+			Expect(func() { c.Timer(2) }).To(PanicWith(
+				"clock.Mock has no timer-2"))
+			Expect(func() { c.Ticker(1) }).To(PanicWith(
+				"clock.Mock has no ticker-1"))
+			c.Stop()
+		})
+	})
+
+	Describe("Labeled", func() {
+		It("scripts and names by the label", func() {
+			const dn = DefaultScriptNow
+			c.Virtual = true
+			c.Strict = true
+			c.TimerScripts = [][]Script{{{Now: 2 * dn}}}
+			c.LabelScripts = map[string][][]Script{
+				"retry": {{{Now: 3 * dn}, {Now: 4 * dn}}},
+				"poll":  {{{Now: 5 * dn}}},
+			}
+			c.Start(tm)
+			lc := Labeled(c, "retry")
+			t1 := lc.NewTimer(time.Second)
+			t2 := c.NewTimer(time.Second)
+			t3 := Labeled(lc, "poll").NewTicker(time.Second)
+			Expect(c.Label("retry", 1).Duration()).To(Equal(time.Second))
+			t1.Reset(2 * time.Second)
+			t1.Stop()
+			t2.Stop()
+			t3.Stop()
+			c.Stop()
+
+			Expect(c.Calls()).To(Equal([]string{
+				"timer retry 1s",
+				"timer 1s",
+				"ticker poll 1s",
+				"retry-1.reset 2s",
+				"retry-1.stop",
+				"timer-1.stop",
+				"poll-1.stop",
+			}))
+			Expect(c.Times()).To(Equal([]time.Time{
+				tm.Add(3 * dn),
+				tm.Add(5 * dn),
+				tm.Add(10 * dn),
+				tm.Add(14 * dn),
+			}))
+			Expect(func() { c.Label("retry", 1) }).To(PanicWith(
+				"clock.Mock has no retry-1"))
+		})
+
+		It("reports unused LabelScripts", func() {
+			t := new(fakeT)
+			c.T = t
+			c.Strict = true
+			c.LabelScripts = map[string][][]Script{
+				"retry": {{{}, {}}, {{}}},
+			}
+			c.Start(tm)
+			Labeled(c, "retry").NewTimer(time.Second).Stop()
+			c.Stop()
+
+			Expect(t.Errors()).To(Equal([]string{
+				`clock.Mock: unused LabelScripts["retry"][0][1:]`,
+				`clock.Mock: unused LabelScripts["retry"][1][0:]`,
+			}))
+		})
+
+		It("is ignored by other Clock", func() {
+			rc := New()
+			Expect(Labeled(rc, "retry")).To(BeIdenticalTo(rc))
+		})
+	})
+
+	Describe("Jumps", func() {
+		It("steps the wall clock while timers keep monotonic", func() {
+			const dn = DefaultScriptNow
+			c.Virtual = true
+			c.Jumps = []Jump{
+				{Call: 2, Offset: -time.Hour},
+				{At: tm.Add(10 * time.Second), Offset: time.Minute},
+			}
+			c.Start(tm)
+			t1 := c.NewTimer(5 * time.Second)
+			n1 := c.Now()
+			n2 := c.Now()
+			Expect(n2.Before(n1)).To(BeTrue())
+			Expect(c.Since(n1)).To(Equal(2*dn - time.Hour))
+
+			c.Advance(5 * time.Second)
+			Expect(t1.C).To(Receive(Equal(
+				tm.Add(5*time.Second + dn - time.Hour))))
+			c.Advance(5 * time.Second)
+			Expect(c.Current()).To(Equal(
+				tm.Add(10*time.Second + 4*dn - time.Hour + time.Minute)))
+
+			t2 := c.NewTimer(time.Second)
+			when, _ := t2.Timerable.(interface {
+				Deadline() (time.Time, bool)
+			}).Deadline()
+			c.Set(when)
+			Expect(t2.C).To(Receive(Equal(when)))
+			c.Stop()
+
+			Expect(c.Times()[1]).To(Equal(n1))
+			Expect(c.Times()[2]).To(Equal(n2))
+			Expect(c.MonoTimes()).To(Equal([]time.Duration{
+				dn,
+				2 * dn,
+				3 * dn,
+				4 * dn,
+				5*time.Second + dn,
+				10*time.Second + 5*dn,
+				11*time.Second + 5*dn,
+			}))
+		})
+	})
+
+	Describe("Monotonic", func() {
+		It("makes Sub immune to the jumps", func() {
+			if !MonotonicSupported() {
+				Skip("time.Time layout is not supported")
+			}
+			const dn = DefaultScriptNow
+			c.Virtual = true
+			c.Monotonic = true
+			c.Jumps = []Jump{{Call: 2, Offset: -time.Hour}}
+			c.Start(tm)
+			n1 := c.Now()
+			n2 := c.Now()
+			Expect(n2.Sub(n1)).To(Equal(dn))
+			Expect(n2.Round(0).Sub(n1.Round(0))).To(Equal(dn - time.Hour))
+			Expect(c.Since(n1)).To(Equal(2 * dn))
+			Expect(n2.String()).To(HaveSuffix(" m=+0.002000000"))
+
+			t := c.NewTimer(time.Second)
+			when, _ := t.Timerable.(interface {
+				Deadline() (time.Time, bool)
+			}).Deadline()
+			c.Set(when)
+			Expect(t.C).To(Receive(Equal(when)))
+			c.Stop()
+
+			Expect(c.Times()[1]).To(Equal(n2))
+		})
+	})
+
+	Describe("Virtual", func() {
+		BeforeEach(func() { c.Virtual = true })
+
+		It("fires timer and ticker in deadline order", func() {
+			const dn = DefaultScriptNow
+			c.Start(tm)
+			t1 := c.NewTimer(time.Second)
+			t2 := c.NewTicker(300 * ms)
+			t3 := c.NewTimer(500 * ms)
+			Consistently(t1.C, 20*ms).ShouldNot(Receive())
+
+			c.Advance(time.Second)
+			Expect(t3.C).To(Receive(Equal(tm.Add(3*dn+500*ms))), "t3")
+			Expect(t1.C).To(Receive(Equal(tm.Add(dn+time.Second))), "t1")
+			// the 2nd to 3rd ticks are missed like the real one
+			Expect(t2.C).To(Receive(Equal(tm.Add(2*dn+300*ms))), "t2")
+			Expect(t2.C).NotTo(Receive())
+
+			Expect(t1.Reset(time.Second)).To(BeFalse(), "t1 reset")
+			Expect(t3.Stop()).To(BeFalse(), "t3 stop")
+			t2.Stop()
+			c.Advance(10 * time.Second)
+			Expect(t1.C).To(Receive(Equal(tm.Add(4*dn + 2*time.Second))))
+			Expect(t2.C).NotTo(Receive())
+			c.Stop()
+
+			Expect(c.Calls()).To(Equal([]string{
+				"timer 1s",
+				"ticker 300ms",
+				"timer 500ms",
+				"timer-1.reset 1s",
+				"timer-2.stop",
+				"ticker-1.stop",
+			}))
+			Expect(c.Times()).To(Equal([]time.Time{
+				tm.Add(dn),
+				tm.Add(2 * dn),
+				tm.Add(3 * dn),
+				tm.Add(2*dn + 300*ms),
+				tm.Add(3*dn + 500*ms),
+				tm.Add(dn + time.Second),
+				tm.Add(4*dn + time.Second),
+				tm.Add(4*dn + 2*time.Second),
+			}))
+		})
+
+		It("drops the missed ticks", func() {
+			c.Start(tm)
+			t := c.NewTicker(ms)
+			c.Advance(24 * time.Hour)
+			Expect(t.C).To(Receive(Equal(tm.Add(DefaultScriptNow + ms))))
+			c.Advance(ms)
+			Expect(t.C).To(Receive(Equal(
+				tm.Add(DefaultScriptNow + 24*time.Hour + ms))))
+			t.Stop()
+			c.Stop()
+
+			Expect(c.Events()).To(HaveLen(4))
+			Expect(c.Times()).To(HaveLen(3))
+		})
+
+		It("only fires on Advance or Set", func() {
+			c.NowScripts = []time.Duration{time.Minute}
+			c.Start(tm)
+			t := c.NewTimer(time.Second)
+			c.Now()
+			Expect(t.C).NotTo(Receive())
+
+			c.Set(tm)
+			xt := tm.Add(DefaultScriptNow + time.Second)
+			Expect(t.C).To(Receive(Equal(xt)))
+			Expect(t.Stop()).To(BeFalse())
+			c.Stop()
+		})
+
+		It("fires After and AfterFunc", func() {
+			const dn = DefaultScriptNow
+			c.Start(tm)
+			ch := c.After(2 * time.Second)
+			done := make(chan struct{})
+			c.AfterFunc(3*time.Second, func() { close(done) })
+			c.Advance(2 * time.Second)
+			Expect(ch).To(Receive(Equal(tm.Add(dn + 2*time.Second))))
+			Consistently(done, 20*ms).ShouldNot(BeClosed())
+
+			c.Advance(time.Second)
+			Eventually(done).Should(BeClosed())
+			c.Stop()
+			Expect(c.Calls()).To(Equal([]string{"after 2s", "afterfunc 3s"}))
+			Expect(c.Times()).To(Equal([]time.Time{
+				tm.Add(dn),
+				tm.Add(2 * dn),
+				tm.Add(dn + 2*time.Second),
+				tm.Add(2*dn + 3*time.Second),
+			}))
+		})
+
+		It("returns Sleep at once on non-positive duration", func() {
+			c.Start(tm)
+			c.Sleep(0)
+			c.Sleep(-time.Second)
+			c.Stop()
+			Expect(c.Calls()).To(Equal([]string{"sleep 0s", "sleep -1s"}))
+			Expect(c.Times()).To(Equal([]time.Time{
+				tm.Add(DefaultScriptNow),
+				tm.Add(2 * DefaultScriptNow),
+			}))
+		})
+
+		It("wakes Sleep after the worker blocks", func() {
+			c.Start(tm)
+			woke := make(chan struct{})
+			go func() {
+				c.Sleep(time.Second)
+				close(woke)
+			}()
+			c.BlockUntil(1)
+			c.Advance(time.Second - ms)
+			Consistently(woke, 20*ms).ShouldNot(BeClosed())
+			c.Advance(time.Second)
+			Eventually(woke).Should(BeClosed())
+			c.Stop()
+			Expect(c.Calls()).To(Equal([]string{"sleep 1s"}))
+		})
+
+		It("panics on non-positive Ticker interval", func() {
+			c.Start(tm)
+			Expect(func() { c.NewTicker(0) }).
+				To(PanicWith("non-positive interval for NewTicker"))
+			t := c.NewTicker(time.Second)
+			Expect(func() { t.Reset(-1) }).
+				To(PanicWith("non-positive interval for Ticker.Reset"))
+			c.Stop()
+		})
+	})
+
 	const (
 		stopFirst  = "clock.Mock must be Stop() first"
 		startFirst = "clock.Mock must be Start() first"
@@ -294,6 +1056,11 @@ var _ = Describe("Mock", func() {
 				Expect(func() { c.Times() }).To(PanicWith(stopFirst))
 			})
 		})
+		Describe("Events", func() {
+			It("should panic", func() {
+				Expect(func() { c.Events() }).To(PanicWith(stopFirst))
+			})
+		})
 	})
 
 	Context("forget to Stop()", func() {
@@ -308,5 +1075,10 @@ var _ = Describe("Mock", func() {
 				Expect(func() { c.Times() }).To(PanicWith(stopFirst))
 			})
 		})
+		Describe("Events", func() {
+			It("should panic", func() {
+				Expect(func() { c.Events() }).To(PanicWith(stopFirst))
+			})
+		})
 	})
 })
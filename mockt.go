@@ -0,0 +1,109 @@
+package clock
+
+import (
+	"strings"
+	"time"
+)
+
+// MockOption is the option for NewMockT.
+type MockOption func(*Mock)
+
+// WithVirtual sets Mock Virtual field.
+func WithVirtual() MockOption {
+	return func(m *Mock) { m.Virtual = true }
+}
+
+// WithStrict sets Mock Strict field.
+func WithStrict() MockOption {
+	return func(m *Mock) { m.Strict = true }
+}
+
+// WithAsyncTimerChan sets Mock AsyncTimerChan field.
+func WithAsyncTimerChan() MockOption {
+	return func(m *Mock) { m.AsyncTimerChan = true }
+}
+
+// WithDefault sets Mock Default field.
+func WithDefault(s Script) MockOption {
+	return func(m *Mock) { m.Default = s }
+}
+
+// WithNowScripts sets Mock NowScripts field.
+func WithNowScripts(l ...time.Duration) MockOption {
+	return func(m *Mock) { m.NowScripts = l }
+}
+
+// WithTimerScripts sets Mock TimerScripts field.
+func WithTimerScripts(l ...[]Script) MockOption {
+	return func(m *Mock) { m.TimerScripts = l }
+}
+
+// WithTickerScripts sets Mock TickerScripts field.
+func WithTickerScripts(l ...[]Script) MockOption {
+	return func(m *Mock) { m.TickerScripts = l }
+}
+
+// WithLabelScripts sets Mock LabelScripts field.
+func WithLabelScripts(l map[string][][]Script) MockOption {
+	return func(m *Mock) { m.LabelScripts = l }
+}
+
+// WithJumps sets Mock Jumps field.
+func WithJumps(l ...Jump) MockOption {
+	return func(m *Mock) { m.Jumps = l }
+}
+
+// WithMonotonic sets Mock Monotonic field.
+func WithMonotonic() MockOption {
+	return func(m *Mock) { m.Monotonic = true }
+}
+
+// TestingT is the part of [testing.TB] used by NewMockT.
+type TestingT interface {
+	ErrorReporter
+	Cleanup(func())
+	Failed() bool
+	Logf(format string, args ...any)
+}
+
+// NewMockT returns a started Mock reporting its failures to t.
+//
+// The Mock is stopped on t cleanup, where every Timer/Ticker that is still
+// active is reported as failure. The events are logged when t has failed,
+// and the trace by WriteTrace() is written to a temporary file.
+func NewMockT(t TestingT, start time.Time, opts ...MockOption) *Mock {
+	m := &Mock{T: t}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.Start(start)
+	t.Cleanup(func() { m.cleanup(t) })
+	return m
+}
+
+func (m *Mock) cleanup(t TestingT) {
+	for _, l := range m.Outstanding() {
+		m.fail("%s is not stopped, created at %s", l.Name, l.Site)
+	}
+	m.Stop()
+	if t.Failed() {
+		t.Logf("%s", m.dump())
+		if path, err := m.writeTraceFile(); err != nil {
+			t.Logf("clock.Mock trace: %v", err)
+		} else {
+			t.Logf("clock.Mock trace: %s", path)
+		}
+	}
+}
+
+func (m *Mock) dump() string {
+	var sb strings.Builder
+	sb.WriteString("clock.Mock events:")
+	for _, e := range m.SnapshotEvents() {
+		sb.WriteString("\n  ")
+		sb.WriteString(e.Time.Format(time.RFC3339Nano))
+		sb.WriteString(" ")
+		sb.WriteString(e.String())
+	}
+	return sb.String()
+}
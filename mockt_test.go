@@ -0,0 +1,71 @@
+package clock_test
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	. "github.com/bangzek/clock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewMockT", func() {
+	var t *fakeT
+	tm := time.Date(2021, time.February, 1, 23, 24, 25, 0, time.UTC)
+	BeforeEach(func() { t = new(fakeT) })
+
+	It("starts and stops the mock", func() {
+		c := NewMockT(t, tm, WithVirtual(), WithNowScripts(time.Second))
+		Expect(c.Now()).To(Equal(tm.Add(time.Second)))
+		tr := c.NewTimer(time.Second)
+		c.Advance(time.Second)
+		<-tr.C
+		c.NewTicker(time.Second).Stop()
+		t.RunCleanup()
+
+		Expect(t.Errors()).To(BeEmpty())
+		Expect(t.logs).To(BeEmpty())
+		Expect(c.Calls()).To(Equal([]string{
+			"now",
+			"timer 1s",
+			"ticker 1s",
+			"ticker-1.stop",
+		}))
+	})
+
+	It("reports unstopped Timer and Ticker", func() {
+		c := NewMockT(t, tm, WithVirtual())
+		c.NewTimer(time.Second)
+		c.NewTimer(time.Second).Stop()
+		c.NewTicker(time.Second)
+		t.RunCleanup()
+
+		Expect(t.Errors()).To(HaveExactElements(
+			MatchRegexp(`^clock.Mock: timer-1 is not stopped, `+
+				`created at .*/mockt_test.go:\d+$`),
+			MatchRegexp(`^clock.Mock: ticker-1 is not stopped, `+
+				`created at .*/mockt_test.go:\d+$`),
+		))
+		Expect(t.logs).To(HaveExactElements(
+			"clock.Mock events:\n"+
+				"  2021-02-01T23:24:25.001Z timer 1s\n"+
+				"  2021-02-01T23:24:25.002Z timer 1s\n"+
+				"  2021-02-01T23:24:25.002Z timer-2.stop\n"+
+				"  2021-02-01T23:24:25.003Z ticker 1s",
+			HavePrefix("clock.Mock trace: "),
+		))
+		path := strings.TrimPrefix(t.logs[1], "clock.Mock trace: ")
+		DeferCleanup(os.Remove, path)
+		Expect(path).To(BeAnExistingFile())
+	})
+
+	It("reports strict failure", func() {
+		c := NewMockT(t, tm, WithStrict(), WithDefault(Script{Now: ms}))
+		c.Now()
+		t.RunCleanup()
+		Expect(t.Errors()).To(HaveExactElements(
+			HavePrefix("clock.Mock: unscripted now call #1 at "),
+		))
+	})
+})
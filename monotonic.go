@@ -0,0 +1,72 @@
+package clock
+
+import (
+	"time"
+	"unsafe"
+)
+
+// The time package has no way to build a Time with a given monotonic
+// reading, so Mock.Monotonic writes it through unsafe. This is acceptable
+// since it's only for testing, and the layout is verified against the
+// running time package once, so a mismatch disables it instead of corrupting
+// any Time, see MonotonicSupported().
+
+// timeLayout mirrors [time.Time] internal layout, where the wall field holds
+// the monotonic flag, 33 bits seconds since 1885 and 30 bits nanoseconds
+// while the ext field holds the monotonic reading.
+type timeLayout struct {
+	wall uint64
+	ext  int64
+	loc  *time.Location
+}
+
+const (
+	hasMonotonic = 1 << 63
+	nsecShift    = 30
+	nsecMask     = 1<<nsecShift - 1
+	// seconds from 1885 to 1970
+	unixToWall int64 = (1969*365 + 1969/4 - 1969/100 + 1969/400 -
+		(1884*365 + 1884/4 - 1884/100 + 1884/400)) * 86400
+)
+
+// monoSupported reports whether the layout hack works on this runtime.
+var monoSupported = func() bool {
+	if unsafe.Sizeof(time.Time{}) != unsafe.Sizeof(timeLayout{}) {
+		return false
+	}
+	now := time.Now()
+	l := (*timeLayout)(unsafe.Pointer(&now))
+	if l.wall&hasMonotonic == 0 ||
+		int64(l.wall<<1>>(nsecShift+1))-unixToWall != now.Unix() ||
+		int(l.wall&nsecMask) != now.Nanosecond() {
+		return false
+	}
+	a, b := now.Round(0), now.Round(0).Add(time.Hour)
+	a, b = setMono(a, 0), setMono(b, int64(time.Second))
+	return b.Sub(a) == time.Second
+}()
+
+// MonotonicSupported reports whether Mock.Monotonic field works on this
+// runtime, which depends on the [time.Time] internal layout.
+func MonotonicSupported() bool {
+	return monoSupported
+}
+
+// setMono returns t with monotonic reading mono, or t as is if it's out of
+// the range of years 1885 through 2157.
+func setMono(t time.Time, mono int64) time.Time {
+	sec := t.Unix() + unixToWall
+	if sec < 0 || sec >= 1<<33 {
+		return t
+	}
+	l := (*timeLayout)(unsafe.Pointer(&t))
+	l.wall = hasMonotonic | uint64(sec)<<nsecShift | uint64(t.Nanosecond())
+	l.ext = mono
+	return t
+}
+
+// getMono returns the monotonic reading of t if any.
+func getMono(t time.Time) (int64, bool) {
+	l := (*timeLayout)(unsafe.Pointer(&t))
+	return l.ext, l.wall&hasMonotonic != 0
+}
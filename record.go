@@ -0,0 +1,332 @@
+package clock
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// RecordingClock is a Clock wrapper writing every call and Timer/Ticker fire
+// as a JSON Event per line to a writer, so the recording can be replayed by
+// Mock, see LoadRecording(). The timestamps are from the wrapped Clock.
+//
+// The Timer/Ticker numbering is the same as Mock, and the Timer/Ticker
+// channel follows Go 1.23 semantics regardless of the wrapped Clock.
+type RecordingClock struct {
+	Clock
+	enc     *json.Encoder
+	err     error
+	nTimer  int
+	nTicker int
+	nSleep  int
+	nLabel  map[string]int
+	lock    sync.Mutex
+}
+
+// NewRecordingClock returns a new RecordingClock wrapping c, usually New(),
+// writing to w.
+func NewRecordingClock(c Clock, w io.Writer) *RecordingClock {
+	return &RecordingClock{Clock: c, enc: json.NewEncoder(w)}
+}
+
+// Err returns the first write error if any.
+func (c *RecordingClock) Err() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.err
+}
+
+func (c *RecordingClock) Now() time.Time {
+	t := c.Clock.Now()
+	c.write(Event{Kind: KindNow, Name: "now", Time: t})
+	return t
+}
+
+func (c *RecordingClock) Since(t time.Time) time.Duration {
+	now := c.Clock.Now()
+	c.write(Event{Kind: KindNow, Name: "since", Time: now})
+	return now.Sub(t)
+}
+
+func (c *RecordingClock) Until(t time.Time) time.Duration {
+	now := c.Clock.Now()
+	c.write(Event{Kind: KindNow, Name: "until", Time: now})
+	return t.Sub(now)
+}
+
+func (c *RecordingClock) Sleep(d time.Duration) {
+	o := c.add("sleep", "")
+	o.write(KindNew, d, false)
+	c.Clock.Sleep(d)
+	o.write(KindFire, 0, false)
+}
+
+func (c *RecordingClock) NewTimer(d time.Duration) *Timer {
+	return c.newTimer("timer", "", d)
+}
+
+func (c *RecordingClock) After(d time.Duration) <-chan time.Time {
+	return c.newTimer("after", "", d).C
+}
+
+func (c *RecordingClock) AfterFunc(d time.Duration, f func()) *Timer {
+	return c.afterFunc("", d, f)
+}
+
+func (c *RecordingClock) NewTicker(d time.Duration) *Ticker {
+	return c.newTicker("", d)
+}
+
+func (c *RecordingClock) newTimer(name, label string, d time.Duration) *Timer {
+	t := &recTimer{recObj: c.add(name, label), tc: newTimerChan(true, false)}
+	t.start(d)
+	return &Timer{Timerable: t, C: t.tc.ch}
+}
+
+func (c *RecordingClock) afterFunc(label string, d time.Duration,
+	f func(),
+) *Timer {
+	t := &recTimer{recObj: c.add("afterfunc", label), f: f}
+	t.start(d)
+	return &Timer{Timerable: t}
+}
+
+func (c *RecordingClock) newTicker(label string, d time.Duration) *Ticker {
+	o := c.add("ticker", label)
+	o.write(KindNew, d, false)
+	t := &recTicker{
+		recObj: o,
+		src:    c.Clock.NewTicker(d),
+		tc:     newTimerChan(true, false),
+	}
+	t.start()
+	return &Ticker{Tickerable: t, C: t.tc.ch}
+}
+
+func (c *RecordingClock) add(name, label string) *recObj {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	o := &recObj{rec: c, name: name, label: label}
+	switch {
+	case label != "":
+		if c.nLabel == nil {
+			c.nLabel = make(map[string]int)
+		}
+		c.nLabel[label]++
+		o.no = c.nLabel[label]
+	case name == "ticker":
+		c.nTicker++
+		o.no = c.nTicker
+	case name == "sleep":
+		c.nSleep++
+		o.no = c.nSleep
+	default:
+		c.nTimer++
+		o.no = c.nTimer
+	}
+	return o
+}
+
+func (c *RecordingClock) write(e Event) {
+	e.Real = time.Now()
+	c.lock.Lock()
+	if err := c.enc.Encode(e); err != nil && c.err == nil {
+		c.err = err
+	}
+	c.lock.Unlock()
+}
+
+func (c *RecordingClock) withLabel(label string) Clock {
+	return &labeledRecording{RecordingClock: c, label: label}
+}
+
+// ===========================================================================
+
+type labeledRecording struct {
+	*RecordingClock
+	label string
+}
+
+func (c *labeledRecording) NewTimer(d time.Duration) *Timer {
+	return c.newTimer("timer", c.label, d)
+}
+
+func (c *labeledRecording) After(d time.Duration) <-chan time.Time {
+	return c.newTimer("after", c.label, d).C
+}
+
+func (c *labeledRecording) AfterFunc(d time.Duration, f func()) *Timer {
+	return c.afterFunc(c.label, d, f)
+}
+
+func (c *labeledRecording) NewTicker(d time.Duration) *Ticker {
+	return c.newTicker(c.label, d)
+}
+
+// ===========================================================================
+
+type recObj struct {
+	rec   *RecordingClock
+	name  string
+	label string
+	no    int
+}
+
+func (o *recObj) write(k Kind, d time.Duration, ret bool) {
+	o.writeAt(k, d, ret, current(o.rec.Clock))
+}
+
+func (o *recObj) writeAt(k Kind, d time.Duration, ret bool, t time.Time) {
+	o.rec.write(Event{
+		Kind:     k,
+		Name:     o.name,
+		Label:    o.label,
+		No:       o.no,
+		Duration: d,
+		Time:     t,
+		Ret:      ret,
+	})
+}
+
+// ===========================================================================
+
+type recTimer struct {
+	Timerable
+	*recObj
+	tc      timerChan
+	f       func() // AfterFunc only
+	pending bool
+	stale   int
+	lock    sync.Mutex
+}
+
+func (t *recTimer) start(d time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.write(KindNew, d, false)
+	t.pending = true
+	t.Timerable = t.rec.Clock.AfterFunc(d, t.fire)
+}
+
+func (t *recTimer) fire() {
+	t.lock.Lock()
+	run := true
+	if t.stale > 0 {
+		// it has been handled by stop()
+		t.stale--
+	} else if t.pending {
+		t.pending = false
+		now := current(t.rec.Clock)
+		t.writeAt(KindFire, 0, false, now)
+		if t.f == nil {
+			t.tc.send(now)
+		}
+	} else {
+		run = false
+	}
+	t.lock.Unlock()
+
+	if run && t.f != nil {
+		t.f()
+	}
+}
+
+// stop must be called with t.lock held. The fire which is already running
+// is marked stale, so it's dropped like Go 1.23 timer, or it's written before
+// the stop for AfterFunc.
+func (t *recTimer) stop() bool {
+	ret := t.Timerable.Stop()
+	if !ret && t.pending {
+		t.stale++
+		if t.f != nil {
+			t.write(KindFire, 0, false)
+		} else {
+			ret = true
+		}
+	}
+	t.pending = false
+	if t.tc.drain() {
+		ret = true
+	}
+	return ret
+}
+
+func (t *recTimer) Stop() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	ret := t.stop()
+	t.write(KindStop, 0, ret)
+	return ret
+}
+
+func (t *recTimer) Reset(d time.Duration) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	ret := t.stop()
+	t.write(KindReset, d, ret)
+	t.pending = true
+	t.Timerable.Reset(d)
+	return ret
+}
+
+// ===========================================================================
+
+type recTicker struct {
+	*recObj
+	src  *Ticker
+	tc   timerChan
+	done chan struct{}
+	exit chan struct{}
+	lock sync.Mutex
+}
+
+// start must be called with t.lock held or before t is shared.
+func (t *recTicker) start() {
+	t.done = make(chan struct{})
+	t.exit = make(chan struct{})
+	go t.forward(t.done, t.exit)
+}
+
+func (t *recTicker) forward(done, exit chan struct{}) {
+	defer close(exit)
+	for {
+		select {
+		case v := <-t.src.C:
+			t.writeAt(KindFire, 0, false, v)
+			t.tc.send(v)
+		case <-done:
+			return
+		}
+	}
+}
+
+func (t *recTicker) Stop() {
+	t.lock.Lock()
+	t.src.Stop()
+	if t.done != nil {
+		// no fire is written after the stop
+		close(t.done)
+		<-t.exit
+		t.done = nil
+	}
+	t.tc.drain()
+	t.lock.Unlock()
+	t.write(KindStop, 0, false)
+}
+
+func (t *recTicker) Reset(d time.Duration) {
+	t.src.Reset(d)
+	t.lock.Lock()
+	if t.done == nil {
+		t.start()
+	}
+	t.tc.drain()
+	t.lock.Unlock()
+	t.write(KindReset, d, false)
+}
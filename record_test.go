@@ -0,0 +1,140 @@
+package clock_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	. "github.com/bangzek/clock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RecordingClock", func() {
+	// run is the code under test, it returns every Now().
+	run := func(c Clock, pause func()) []time.Time {
+		var l []time.Time
+		l = append(l, c.Now())
+		t := c.NewTimer(time.Hour)
+		pause()
+		t.Reset(time.Hour)
+		l = append(l, c.Now())
+		t.Stop()
+		pause()
+		tk := Labeled(c, "poll").NewTicker(time.Hour)
+		pause()
+		l = append(l, c.Now())
+		tk.Stop()
+		return l
+	}
+
+	It("records and replays", func() {
+		var buf bytes.Buffer
+		rc := NewRecordingClock(New(), &buf)
+		nows := run(rc, func() { time.Sleep(ms) })
+		Expect(rc.Err()).NotTo(HaveOccurred())
+
+		var calls []string
+		sc := bufio.NewScanner(bytes.NewReader(buf.Bytes()))
+		for sc.Scan() {
+			var e Event
+			Expect(json.Unmarshal(sc.Bytes(), &e)).To(Succeed())
+			calls = append(calls, e.String())
+		}
+		Expect(calls).To(Equal([]string{
+			"now",
+			"timer 1h0m0s",
+			"timer-1.reset 1h0m0s",
+			"now",
+			"timer-1.stop",
+			"ticker poll 1h0m0s",
+			"now",
+			"poll-1.stop",
+		}))
+
+		rp, err := LoadRecording(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		t := new(fakeT)
+		c := NewMockT(t, rp.Start,
+			append(rp.Options(), WithVirtual(), WithStrict())...)
+		l := run(c, func() {})
+		t.RunCleanup()
+
+		Expect(t.Errors()).To(BeEmpty())
+		Expect(c.Calls()).To(Equal(calls))
+		Expect(l).To(HaveLen(len(nows)))
+		for i := range l {
+			Expect(l[i].Equal(nows[i])).To(BeTrue(), "now #%d", i+1)
+		}
+	})
+
+	It("records the fire", func() {
+		var buf bytes.Buffer
+		rc := NewRecordingClock(New(), &buf)
+		t := rc.NewTimer(ms)
+		Eventually(t.C).Should(Receive())
+		done := make(chan struct{})
+		rc.AfterFunc(ms, func() { close(done) })
+		Eventually(done).Should(BeClosed())
+		tk := rc.NewTicker(ms)
+		Eventually(tk.C).Should(Receive())
+		tk.Stop()
+
+		s := buf.String()
+		Expect(s).To(ContainSubstring(`"Kind":"fire","Name":"timer"`))
+		Expect(s).To(ContainSubstring(`"Kind":"fire","Name":"afterfunc"`))
+		Expect(s).To(ContainSubstring(`"Kind":"fire","Name":"ticker"`))
+	})
+
+	It("drops the fire racing with Stop", func() {
+		m := &Mock{Virtual: true}
+		m.Start(time.Now())
+		for range 20 {
+			var buf bytes.Buffer
+			rc := NewRecordingClock(m, &buf)
+			t := rc.NewTimer(time.Second)
+			m.Advance(time.Second)
+			Expect(t.Stop()).To(BeTrue())
+			m.Wait()
+			Expect(t.C).NotTo(Receive())
+
+			t.Reset(time.Hour)
+			m.Advance(time.Second)
+			Expect(t.C).NotTo(Receive())
+			m.Wait()
+			Expect(t.C).NotTo(Receive())
+			// the fire may happen before the stop, but never after
+			_, after, _ := strings.Cut(buf.String(), `"Kind":"stop"`)
+			Expect(after).NotTo(ContainSubstring(`"Kind":"fire"`))
+		}
+		m.Stop()
+	})
+
+	It("doesn't call the Mock Now()", func() {
+		m := &Mock{Virtual: true}
+		m.Start(time.Now())
+		rc := NewRecordingClock(m, io.Discard)
+		t := rc.NewTimer(time.Second)
+		m.Advance(time.Second)
+		m.Wait()
+		Expect(t.C).To(Receive())
+		t.Stop()
+		m.Stop()
+		Expect(m.Calls()).To(Equal([]string{
+			"afterfunc 1s",
+			"afterfunc-1.stop",
+		}))
+	})
+
+	It("fails on invalid recording", func() {
+		_, err := LoadRecording(bytes.NewBufferString(
+			`{"Kind":"now"}` + "\n" + `{"Kind":"bogus"}` + "\n"))
+		Expect(err).To(MatchError(And(
+			HavePrefix("clock.LoadRecording: line 2: "),
+			HaveSuffix(`clock: unknown event kind "bogus"`),
+		)))
+	})
+})
@@ -0,0 +1,98 @@
+package clock
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Replay is the Mock scripts built from a RecordingClock recording, see
+// LoadRecording().
+type Replay struct {
+	// The mocked time to Start() with.
+	Start         time.Time
+	NowScripts    []time.Duration
+	TimerScripts  [][]Script
+	TickerScripts [][]Script
+	LabelScripts  map[string][][]Script
+}
+
+// LoadRecording reads a RecordingClock recording and builds the Mock scripts
+// advancing the time of each call by the same duration as recorded, so the
+// code under test sees the same sequence of time. The gap less than 1ns is
+// replayed as 1ns since zero script means the default. Sleep uses Default
+// script, so it's not replayed exactly.
+func LoadRecording(r io.Reader) (*Replay, error) {
+	var rp Replay
+	var last time.Time
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	for line := 1; sc.Scan(); line++ {
+		var e Event
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("clock.LoadRecording: line %d: %w",
+				line, err)
+		}
+		if line == 1 {
+			rp.Start = e.Time.Add(-1)
+			last = rp.Start
+		}
+		if e.Kind == KindStop {
+			// Stop() doesn't move the mocked time
+			continue
+		}
+		d := max(e.Time.Sub(last), 1)
+		if e.Time.After(last) {
+			last = e.Time
+		}
+		if e.Kind == KindFire || e.Name == "sleep" {
+			continue
+		}
+		if e.Kind == KindNow {
+			rp.NowScripts = append(rp.NowScripts, d)
+			continue
+		}
+		if e.No < 1 {
+			return nil, fmt.Errorf("clock.LoadRecording: line %d: "+
+				"invalid %s number %d", line, e.Name, e.No)
+		}
+		s := Script{Now: d}
+		switch {
+		case e.Label != "":
+			if rp.LabelScripts == nil {
+				rp.LabelScripts = make(map[string][][]Script)
+			}
+			rp.LabelScripts[e.Label] = addScript(rp.LabelScripts[e.Label],
+				e.No, s)
+		case e.Name == "ticker":
+			rp.TickerScripts = addScript(rp.TickerScripts, e.No, s)
+		default:
+			rp.TimerScripts = addScript(rp.TimerScripts, e.No, s)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("clock.LoadRecording: %w", err)
+	}
+	return &rp, nil
+}
+
+func addScript(l [][]Script, no int, s Script) [][]Script {
+	for len(l) < no {
+		l = append(l, nil)
+	}
+	l[no-1] = append(l[no-1], s)
+	return l
+}
+
+// Options returns the MockOption to replay by NewMockT, use it with
+// WithVirtual() to be deterministic.
+func (rp *Replay) Options() []MockOption {
+	return []MockOption{
+		WithNowScripts(rp.NowScripts...),
+		WithTimerScripts(rp.TimerScripts...),
+		WithTickerScripts(rp.TickerScripts...),
+		WithLabelScripts(rp.LabelScripts),
+	}
+}
@@ -0,0 +1,373 @@
+package clock
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadScenario reads a JSON or YAML scenario file and returns a Mock started
+// with its configuration, e.g.
+//
+//	start: 2021-02-01T23:24:25Z
+//	virtual: true
+//	strict: true
+//	default: {now: 1ms, ratio: 10}
+//	now: [1s, 500ms]
+//	timers:
+//	  - [{now: 1ms}, {now: 2ms, ratio: 5}]
+//	tickers:
+//	  - [{now: 1ms}]
+//	labels:
+//	  retry: [[{now: 1s}]]
+//	jumps:
+//	  - {call: 2, offset: -1h}
+//	  - {at: 2021-02-01T23:30:00Z, offset: 1m}
+//
+// Only start is required, the durations are as [time.ParseDuration]. The
+// error is reported with the file name and line number. The Mock T field can
+// be set afterward.
+func LoadScenario(path string) (*Mock, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("clock.LoadScenario: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("clock.LoadScenario: %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, fmt.Errorf("clock.LoadScenario: %s: empty document", path)
+	}
+
+	p := scenarioParser{path: path}
+	m, start := new(Mock), time.Time{}
+	root := doc.Content[0]
+	if err := p.mapping(root, func(k string, n *yaml.Node) error {
+		var err error
+		switch k {
+		case "start":
+			start, err = p.time(n)
+		case "virtual":
+			m.Virtual, err = p.bool(n)
+		case "strict":
+			m.Strict, err = p.bool(n)
+		case "asyncTimerChan":
+			m.AsyncTimerChan, err = p.bool(n)
+		case "monotonic":
+			m.Monotonic, err = p.bool(n)
+		case "default":
+			m.Default, err = p.script(n)
+		case "now":
+			err = p.sequence(n, func(n *yaml.Node) error {
+				d, err := p.duration(n, false)
+				m.NowScripts = append(m.NowScripts, d)
+				return err
+			})
+		case "timers":
+			m.TimerScripts, err = p.scripts(n)
+		case "tickers":
+			m.TickerScripts, err = p.scripts(n)
+		case "labels":
+			m.LabelScripts = make(map[string][][]Script)
+			err = p.mapping(n, func(k string, n *yaml.Node) error {
+				l, err := p.scripts(n)
+				m.LabelScripts[k] = l
+				return err
+			})
+		case "jumps":
+			err = p.sequence(n, func(n *yaml.Node) error {
+				j, err := p.jump(n)
+				m.Jumps = append(m.Jumps, j)
+				return err
+			})
+		default:
+			err = p.errorf(n, "unknown field %q", k)
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	if start.IsZero() {
+		return nil, p.errorf(root, "missing start")
+	}
+	m.Start(start)
+	return m, nil
+}
+
+// SaveScenario writes the Mock configuration to a scenario file readable by
+// LoadScenario(), it's JSON when the path has .json extension or YAML
+// otherwise. The start is the time given to Start().
+func (m *Mock) SaveScenario(path string) error {
+	m.tLock.Lock()
+	sc := scenario{
+		Start:          m.start,
+		Virtual:        m.Virtual,
+		Strict:         m.Strict,
+		AsyncTimerChan: m.AsyncTimerChan,
+		Monotonic:      m.Monotonic,
+		Timers:         scenarioScriptsOf(m.TimerScripts),
+		Tickers:        scenarioScriptsOf(m.TickerScripts),
+	}
+	m.tLock.Unlock()
+	if m.Default != (Script{}) {
+		s := scenarioScriptOf(m.Default)
+		sc.Default = &s
+	}
+	for _, d := range m.NowScripts {
+		sc.Now = append(sc.Now, d.String())
+	}
+	if len(m.LabelScripts) > 0 {
+		sc.Labels = make(map[string]scenarioScripts)
+		for k, l := range m.LabelScripts {
+			sc.Labels[k] = scenarioScriptsOf(l)
+		}
+	}
+	for _, j := range m.Jumps {
+		sj := scenarioJump{Call: j.Call, Offset: j.Offset.String()}
+		if j.Call <= 0 {
+			sj.At = &j.At
+		}
+		sc.Jumps = append(sc.Jumps, sj)
+	}
+
+	b, err := json.MarshalIndent(sc, "", "  ")
+	b = append(b, '\n')
+	if err == nil && !strings.EqualFold(filepath.Ext(path), ".json") {
+		var doc yaml.Node
+		if err = yaml.Unmarshal(b, &doc); err == nil {
+			blockStyle(&doc)
+			b, err = yaml.Marshal(&doc)
+		}
+	}
+	if err == nil {
+		err = os.WriteFile(path, b, 0o666)
+	}
+	if err != nil {
+		return fmt.Errorf("clock.Mock.SaveScenario: %w", err)
+	}
+	return nil
+}
+
+// ===========================================================================
+
+type scenario struct {
+	Start          time.Time                  `json:"start"`
+	Virtual        bool                       `json:"virtual,omitempty"`
+	Strict         bool                       `json:"strict,omitempty"`
+	AsyncTimerChan bool                       `json:"asyncTimerChan,omitempty"`
+	Monotonic      bool                       `json:"monotonic,omitempty"`
+	Default        *scenarioScript            `json:"default,omitempty"`
+	Now            []string                   `json:"now,omitempty"`
+	Timers         scenarioScripts            `json:"timers,omitempty"`
+	Tickers        scenarioScripts            `json:"tickers,omitempty"`
+	Labels         map[string]scenarioScripts `json:"labels,omitempty"`
+	Jumps          []scenarioJump             `json:"jumps,omitempty"`
+}
+
+type scenarioScript struct {
+	Now   string `json:"now,omitempty"`
+	Ratio int64  `json:"ratio,omitempty"`
+}
+
+type scenarioScripts [][]scenarioScript
+
+type scenarioJump struct {
+	Call   int        `json:"call,omitempty"`
+	At     *time.Time `json:"at,omitempty"`
+	Offset string     `json:"offset"`
+}
+
+func scenarioScriptOf(s Script) scenarioScript {
+	var ss scenarioScript
+	if s.Now != 0 {
+		ss.Now = s.Now.String()
+	}
+	ss.Ratio = int64(s.Ratio)
+	return ss
+}
+
+func scenarioScriptsOf(l [][]Script) scenarioScripts {
+	if len(l) == 0 {
+		return nil
+	}
+	sl := make(scenarioScripts, len(l))
+	for i, ss := range l {
+		sl[i] = make([]scenarioScript, len(ss))
+		for j, s := range ss {
+			sl[i][j] = scenarioScriptOf(s)
+		}
+	}
+	return sl
+}
+
+// blockStyle restyles the YAML parsed from JSON, only the mapping of scalars
+// stays in flow style.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	flow := n.Kind == yaml.MappingNode
+	for _, c := range n.Content {
+		blockStyle(c)
+		flow = flow && c.Kind == yaml.ScalarNode
+	}
+	if flow {
+		n.Style = yaml.FlowStyle
+	}
+}
+
+// ===========================================================================
+
+type scenarioParser struct {
+	path string
+}
+
+func (p *scenarioParser) errorf(n *yaml.Node, format string, a ...any) error {
+	return fmt.Errorf("clock.LoadScenario: %s:%d: %s", p.path, n.Line,
+		fmt.Sprintf(format, a...))
+}
+
+func (p *scenarioParser) mapping(n *yaml.Node,
+	f func(k string, n *yaml.Node) error,
+) error {
+	if n.Kind != yaml.MappingNode {
+		return p.errorf(n, "expected mapping")
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if err := f(n.Content[i].Value, n.Content[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *scenarioParser) sequence(n *yaml.Node,
+	f func(n *yaml.Node) error,
+) error {
+	if n.Kind != yaml.SequenceNode {
+		return p.errorf(n, "expected sequence")
+	}
+	for _, c := range n.Content {
+		if err := f(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *scenarioParser) scalar(n *yaml.Node) (string, error) {
+	if n.Kind != yaml.ScalarNode {
+		return "", p.errorf(n, "expected scalar")
+	}
+	return n.Value, nil
+}
+
+func (p *scenarioParser) bool(n *yaml.Node) (bool, error) {
+	s, err := p.scalar(n)
+	if err != nil {
+		return false, err
+	}
+	if n.Tag != "!!bool" {
+		return false, p.errorf(n, "invalid bool %q", s)
+	}
+	return s == "true", nil
+}
+
+func (p *scenarioParser) int(n *yaml.Node) (int64, error) {
+	s, err := p.scalar(n)
+	if err != nil {
+		return 0, err
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || i < 0 {
+		return 0, p.errorf(n, "invalid non-negative integer %q", s)
+	}
+	return i, nil
+}
+
+func (p *scenarioParser) time(n *yaml.Node) (time.Time, error) {
+	s, err := p.scalar(n)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, p.errorf(n, "invalid RFC 3339 time %q", s)
+	}
+	return t, nil
+}
+
+func (p *scenarioParser) duration(n *yaml.Node, negative bool) (
+	time.Duration, error,
+) {
+	s, err := p.scalar(n)
+	if err != nil {
+		return 0, err
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 && !negative {
+		return 0, p.errorf(n, "invalid duration %q", s)
+	}
+	return d, nil
+}
+
+func (p *scenarioParser) script(n *yaml.Node) (Script, error) {
+	var s Script
+	err := p.mapping(n, func(k string, n *yaml.Node) error {
+		var err error
+		switch k {
+		case "now":
+			s.Now, err = p.duration(n, false)
+		case "ratio":
+			var i int64
+			i, err = p.int(n)
+			s.Ratio = time.Duration(i)
+		default:
+			err = p.errorf(n, "unknown script field %q", k)
+		}
+		return err
+	})
+	return s, err
+}
+
+func (p *scenarioParser) scripts(n *yaml.Node) ([][]Script, error) {
+	var l [][]Script
+	err := p.sequence(n, func(n *yaml.Node) error {
+		var ss []Script
+		err := p.sequence(n, func(n *yaml.Node) error {
+			s, err := p.script(n)
+			ss = append(ss, s)
+			return err
+		})
+		l = append(l, slices.Clip(ss))
+		return err
+	})
+	return l, err
+}
+
+func (p *scenarioParser) jump(n *yaml.Node) (Jump, error) {
+	var j Jump
+	err := p.mapping(n, func(k string, n *yaml.Node) error {
+		var err error
+		switch k {
+		case "call":
+			var i int64
+			i, err = p.int(n)
+			j.Call = int(i)
+		case "at":
+			j.At, err = p.time(n)
+		case "offset":
+			j.Offset, err = p.duration(n, true)
+		default:
+			err = p.errorf(n, "unknown jump field %q", k)
+		}
+		return err
+	})
+	return j, err
+}
@@ -0,0 +1,101 @@
+package clock_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/bangzek/clock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scenario", func() {
+	var dir string
+	tm := time.Date(2021, time.February, 1, 23, 24, 25, 0, time.UTC)
+	BeforeEach(func() { dir = GinkgoT().TempDir() })
+
+	write := func(name, s string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte(s), 0o666)).To(Succeed())
+		return path
+	}
+	config := func(m *Mock) Mock {
+		return Mock{
+			NowScripts:    m.NowScripts,
+			TimerScripts:  m.TimerScripts,
+			TickerScripts: m.TickerScripts,
+			LabelScripts:  m.LabelScripts,
+			Default:       m.Default,
+			Virtual:       m.Virtual,
+			Strict:        m.Strict,
+			Jumps:         m.Jumps,
+		}
+	}
+
+	It("loads and saves", func() {
+		m, err := LoadScenario(write("s.yaml", `
+start: 2021-02-01T23:24:25Z
+virtual: true
+strict: true
+default: {now: 1ms, ratio: 10}
+now: [1s, 500ms]
+timers:
+  - [{now: 1ms}, {now: 2ms, ratio: 5}]
+  - []
+tickers:
+  - [{ratio: 2}]
+labels:
+  retry: [[{now: 1s}]]
+jumps:
+  - {call: 2, offset: -1h}
+  - {at: 2021-02-01T23:30:00Z, offset: 1m}
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config(m)).To(Equal(Mock{
+			NowScripts: []time.Duration{time.Second, 500 * ms},
+			TimerScripts: [][]Script{
+				{{Now: ms}, {Now: 2 * ms, Ratio: 5}},
+				nil,
+			},
+			TickerScripts: [][]Script{{{Ratio: 2}}},
+			LabelScripts: map[string][][]Script{
+				"retry": {{{Now: time.Second}}},
+			},
+			Default: Script{Now: ms, Ratio: 10},
+			Virtual: true,
+			Strict:  true,
+			Jumps: []Jump{
+				{Call: 2, Offset: -time.Hour},
+				{At: tm.Add(5*time.Minute + 35*time.Second),
+					Offset: time.Minute},
+			},
+		}))
+		Expect(m.Current()).To(Equal(tm))
+
+		for _, name := range []string{"out.json", "out.yaml"} {
+			path := filepath.Join(dir, name)
+			Expect(m.SaveScenario(path)).To(Succeed())
+			m2, err := LoadScenario(path)
+			Expect(err).NotTo(HaveOccurred(), name)
+			Expect(config(m2)).To(Equal(config(m)), name)
+			Expect(m2.Current()).To(Equal(tm), name)
+		}
+	})
+
+	It("reports the error line", func() {
+		_, err := LoadScenario(write("s.json", `{
+  "start": "2021-02-01T23:24:25Z",
+  "timers": [[{"now": "1ms"}, {"now": "1 ms"}]]
+}`))
+		Expect(err).To(MatchError(HaveSuffix(
+			`/s.json:3: invalid duration "1 ms"`)))
+
+		_, err = LoadScenario(write("s.yaml", "virtual: true\nvirtal: true\n"))
+		Expect(err).To(MatchError(HaveSuffix(
+			`/s.yaml:2: unknown field "virtal"`)))
+
+		_, err = LoadScenario(write("s.yaml", "virtual: true\n"))
+		Expect(err).To(MatchError(HaveSuffix(`/s.yaml:1: missing start`)))
+	})
+})
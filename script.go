@@ -27,11 +27,12 @@ func (s Script) canon() Script {
 	return s
 }
 
-func getScript(l [][]Script, no int, i *int, def Script) Script {
-	if no <= len(l) && len(l[no-1]) > *i {
-		*i++
-		return l[no-1][*i-1].canon()
+// getScript returns the i-th script of no-th object, or def when it's not
+// scripted. The i is always incremented so it counts the calls.
+func getScript(l [][]Script, no int, i *int, def Script) (Script, bool) {
+	if *i++; no <= len(l) && len(l[no-1]) >= *i {
+		return l[no-1][*i-1].canon(), true
 	} else {
-		return def.canon()
+		return def.canon(), false
 	}
 }
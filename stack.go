@@ -0,0 +1,70 @@
+package clock
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Live is an active Timer/Ticker with its creation call stack.
+type Live struct {
+	// The name as in Calls(), e.g. "timer-2".
+	Name string
+	// The location of the creation call, e.g. "/src/app/app.go:12".
+	Site string
+	// The creation call stack.
+	Stack string
+}
+
+func (l Live) String() string {
+	return l.Name + " created at " + l.Site
+}
+
+// pkgPrefix is this package function name prefix, e.g.
+// "github.com/bangzek/clock.".
+var pkgPrefix = func() string {
+	name := runtime.FuncForPC(reflect.ValueOf(getScript).Pointer()).Name()
+	return name[:strings.LastIndexByte(name, '.')+1]
+}()
+
+// callers returns the call stack of the caller.
+func callers() []uintptr {
+	pc := make([]uintptr, 32)
+	return pc[:runtime.Callers(3, pc)]
+}
+
+// caller returns the location of the first caller outside this package.
+func caller() string {
+	site, _ := stackOf(callers())
+	return site
+}
+
+// stackOf returns the location of the first caller outside this package and
+// the formatted call stack.
+func stackOf(pc []uintptr) (site, stack string) {
+	var sb strings.Builder
+	site = "unknown"
+	frames := runtime.CallersFrames(pc)
+	for {
+		f, more := frames.Next()
+		if f.Function != "" {
+			loc := fmt.Sprintf("%s:%d", f.File, f.Line)
+			if site == "unknown" && !strings.HasPrefix(f.Function, pkgPrefix) {
+				site = loc
+			}
+			sb.WriteString(f.Function)
+			sb.WriteString("\n\t")
+			sb.WriteString(loc)
+			sb.WriteString("\n")
+		}
+		if !more {
+			return site, sb.String()
+		}
+	}
+}
+
+func liveOf(name string, pc []uintptr) Live {
+	site, stack := stackOf(pc)
+	return Live{Name: name, Site: site, Stack: stack}
+}
@@ -0,0 +1,68 @@
+package clock
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ErrorReporter is the part of [testing.TB] where Mock reports its failures.
+type ErrorReporter interface {
+	Errorf(format string, args ...any)
+}
+
+func (m *Mock) fail(format string, args ...any) {
+	msg := "clock.Mock: " + fmt.Sprintf(format, args...)
+	if m.T == nil {
+		panic(msg)
+	}
+	m.T.Errorf("%s", msg)
+}
+
+func (m *Mock) unscripted(name string, i int) {
+	if m.Strict {
+		m.fail("unscripted %s call #%d at %s", name, i, caller())
+	}
+}
+
+// checkUnused must be called with m.sLock held, it returns the failures to
+// report after the lock is released.
+func (m *Mock) checkUnused() []string {
+	var l []string
+	add := func(format string, args ...any) {
+		l = append(l, fmt.Sprintf(format, args...))
+	}
+	if m.iNow < len(m.NowScripts) {
+		add("unused NowScripts[%d:]", m.iNow)
+	}
+	for i, l := range m.TimerScripts {
+		used := 0
+		if i < len(m.timers) {
+			used = m.timers[i].i
+		}
+		if used < len(l) {
+			add("unused TimerScripts[%d][%d:]", i, used)
+		}
+	}
+	for i, l := range m.TickerScripts {
+		used := 0
+		if i < len(m.tickers) {
+			used = m.tickers[i].i
+		}
+		if used < len(l) {
+			add("unused TickerScripts[%d][%d:]", i, used)
+		}
+	}
+	for _, label := range slices.Sorted(maps.Keys(m.LabelScripts)) {
+		for i, l := range m.LabelScripts[label] {
+			used := 0
+			if c := m.findLabeled(label, i+1); c != nil {
+				used = c.i
+			}
+			if used < len(l) {
+				add("unused LabelScripts[%q][%d][%d:]", label, i, used)
+			}
+		}
+	}
+	return l
+}
@@ -1,6 +1,8 @@
 package clock_test
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -17,3 +19,48 @@ func TestUtil(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "clock Suite")
 }
+
+// fakeT records the failures instead of failing the test.
+type fakeT struct {
+	errs     []string
+	logs     []string
+	cleanups []func()
+	lock     sync.Mutex
+}
+
+func (t *fakeT) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+// RunCleanup runs the registered cleanup in reverse order.
+func (t *fakeT) RunCleanup() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+	t.cleanups = nil
+}
+
+func (t *fakeT) Failed() bool {
+	return len(t.Errors()) > 0
+}
+
+func (t *fakeT) Logf(format string, args ...any) {
+	t.lock.Lock()
+	t.logs = append(t.logs, fmt.Sprintf(format, args...))
+	t.lock.Unlock()
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.lock.Lock()
+	t.errs = append(t.errs, fmt.Sprintf(format, args...))
+	t.lock.Unlock()
+}
+
+func (t *fakeT) Errors() []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.errs
+}
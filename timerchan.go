@@ -0,0 +1,103 @@
+package clock
+
+import (
+	"runtime"
+	"time"
+)
+
+// timerChan is the Timer/Ticker channel. Unless it's buffered, its methods
+// must be called with the owner lock held.
+//
+// Unless buffered, it's unbuffered like Go 1.23 timer: the value is sent by
+// its own goroutine, so cap() and len() are always 0, and drain() drops the
+// unreceived value so nothing stale is received afterward. The buffered one
+// has capacity 1 like before Go 1.23, and keeps the unreceived value on
+// drain() when async.
+type timerChan struct {
+	ch    chan time.Time
+	async bool
+	stop  chan struct{} // stops the pending send, nil if none
+	sent  chan bool     // the pending send result
+	last  time.Time     // the pending send value
+}
+
+func newTimerChan(buffered, async bool) timerChan {
+	if buffered {
+		return timerChan{ch: make(chan time.Time, 1), async: async}
+	}
+	return timerChan{ch: make(chan time.Time)}
+}
+
+// send sends t unless there is unreceived value.
+func (c *timerChan) send(t time.Time) {
+	if cap(c.ch) > 0 {
+		select {
+		case c.ch <- t:
+		default:
+		}
+		return
+	}
+	if c.stop != nil && !c.stopSend() {
+		// keep the unreceived value like the real one
+		t = c.last
+	}
+	c.last = t
+	ch, stop, sent := c.ch, make(chan struct{}), make(chan bool, 1)
+	ready := make(chan struct{})
+	c.stop, c.sent = stop, sent
+	go func() {
+		close(ready)
+		select {
+		case ch <- t:
+			sent <- true
+		case <-stop:
+			sent <- false
+		}
+	}()
+	<-ready
+	// let the sender block on the channel, so the value can be received
+	// without waiting right after send returns
+	for range 3 {
+		runtime.Gosched()
+	}
+}
+
+// unreceived reports whether there is unreceived value.
+func (c *timerChan) unreceived() bool {
+	if cap(c.ch) > 0 {
+		return len(c.ch) > 0
+	}
+	if c.stop == nil || c.stopSend() {
+		return false
+	}
+	c.send(c.last)
+	return true
+}
+
+// stopSend must be called with the pending send, it reports whether the
+// value has been received.
+func (c *timerChan) stopSend() bool {
+	close(c.stop)
+	received := <-c.sent
+	c.stop, c.sent = nil, nil
+	return received
+}
+
+// drain drops the unreceived value unless async, it reports whether the
+// value is dropped.
+func (c *timerChan) drain() bool {
+	switch {
+	case c.async:
+		return false
+	case cap(c.ch) > 0:
+		select {
+		case <-c.ch:
+			return true
+		default:
+			return false
+		}
+	case c.stop == nil:
+		return false
+	}
+	return !c.stopSend()
+}
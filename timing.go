@@ -0,0 +1,65 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// current returns the time of c without side effects, i.e. by Current()
+// when c has one like Mock, so nothing is logged nor advanced.
+func current(c Clock) time.Time {
+	if cc, ok := c.(interface{ Current() time.Time }); ok {
+		return cc.Current()
+	}
+	return c.Now()
+}
+
+// nextTick returns the next tick after t of the Ticker whose tick when is
+// due. Like the real one, the missed ticks are dropped.
+func nextTick(when, t time.Time, period time.Duration) time.Time {
+	return when.Add((max(t.Sub(when), 0)/period + 1) * period)
+}
+
+// funcGroup runs AfterFunc callbacks in their own goroutines and keeps track
+// of them for wait(). The zero value is ready to use.
+type funcGroup struct {
+	n    int
+	done chan struct{} // closed when n drops to 0, nil if nobody waits
+	lock sync.Mutex
+}
+
+// run runs f in its own goroutine.
+func (g *funcGroup) run(f func()) {
+	g.lock.Lock()
+	g.n++
+	g.lock.Unlock()
+	go func() {
+		defer g.end()
+		f()
+	}()
+}
+
+func (g *funcGroup) end() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.n--; g.n == 0 && g.done != nil {
+		close(g.done)
+		g.done = nil
+	}
+}
+
+// wait blocks until every callback that has been run returns.
+func (g *funcGroup) wait() {
+	g.lock.Lock()
+	if g.n == 0 {
+		g.lock.Unlock()
+		return
+	}
+	if g.done == nil {
+		g.done = make(chan struct{})
+	}
+	done := g.done
+	g.lock.Unlock()
+	<-done
+}
@@ -0,0 +1,130 @@
+package clock
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
+	"time"
+)
+
+// traceEvent is a Chrome Trace Event Format event.
+type traceEvent struct {
+	Name  string         `json:"name"`
+	Ph    string         `json:"ph"`
+	Ts    float64        `json:"ts"`
+	Dur   float64        `json:"dur,omitempty"`
+	Pid   int            `json:"pid"`
+	Tid   int            `json:"tid"`
+	Scope string         `json:"s,omitempty"`
+	Args  map[string]any `json:"args,omitempty"`
+}
+
+// WriteTrace writes the events so far as Chrome Trace Event Format JSON,
+// which can be opened by chrome://tracing or Perfetto UI. The timestamp is
+// the mocked monotonic time since Start(), so it's not affected by Jumps.
+//
+// Every Timer/Ticker has its own track with a span from New or Reset to its
+// fire, Stop or Reset, while Now, Since and Until are instant events on the
+// "clock" track.
+func (m *Mock) WriteTrace(w io.Writer) error {
+	m.cLock.Lock()
+	events := slices.Clone(m.events)
+	monos := slices.Clone(m.eMonos)
+	m.cLock.Unlock()
+	m.tLock.Lock()
+	start := m.start
+	m.tLock.Unlock()
+
+	ts := func(t time.Time) float64 {
+		return float64(t.Sub(start)) / float64(time.Microsecond)
+	}
+	l := []traceEvent{{
+		Name: "thread_name",
+		Ph:   "M",
+		Args: map[string]any{"name": "clock"},
+	}}
+	type span struct {
+		name  string
+		start time.Time
+	}
+	tids := make(map[string]int)
+	spans := make(map[int]*span)
+	end := func(tid int, t time.Time, how string) {
+		if s := spans[tid]; s != nil {
+			l = append(l, traceEvent{
+				Name: s.name,
+				Ph:   "X",
+				Ts:   ts(s.start),
+				Dur:  ts(t) - ts(s.start),
+				Tid:  tid,
+				Args: map[string]any{"end": how},
+			})
+			delete(spans, tid)
+		}
+	}
+	for i, e := range events {
+		mono := monos[i]
+		if e.Kind == KindNow {
+			l = append(l, traceEvent{
+				Name:  e.String(),
+				Ph:    "i",
+				Ts:    ts(mono),
+				Scope: "t",
+			})
+			continue
+		}
+
+		id := e.object()
+		tid, ok := tids[id]
+		if !ok {
+			tid = len(tids) + 1
+			tids[id] = tid
+			l = append(l, traceEvent{
+				Name: "thread_name",
+				Ph:   "M",
+				Tid:  tid,
+				Args: map[string]any{"name": id},
+			})
+		}
+		switch e.Kind {
+		case KindNew, KindReset:
+			end(tid, mono, "reset")
+			spans[tid] = &span{name: e.String(), start: mono}
+		case KindStop:
+			end(tid, mono, "stop")
+		case KindFire:
+			s := spans[tid]
+			end(tid, mono, "fire")
+			if s != nil && e.Name == "ticker" {
+				spans[tid] = &span{name: s.name, start: mono}
+			}
+		}
+	}
+	if len(monos) > 0 {
+		last := monos[len(monos)-1]
+		for tid := 1; tid <= len(tids); tid++ {
+			end(tid, last, "active")
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", " ")
+	return enc.Encode(struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}{l, "ms"})
+}
+
+// writeTraceFile writes the trace to a temporary file and returns its path.
+func (m *Mock) writeTraceFile() (string, error) {
+	f, err := os.CreateTemp("", "clock-trace-*.json")
+	if err != nil {
+		return "", err
+	}
+	err = m.WriteTrace(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return f.Name(), err
+}
@@ -0,0 +1,127 @@
+package clock_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"time"
+
+	. "github.com/bangzek/clock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WriteTrace", func() {
+	It("writes Chrome trace", func() {
+		tm := time.Date(2021, time.February, 1, 23, 24, 25, 0, time.UTC)
+		c := &Mock{Virtual: true, Default: Script{Now: ms}}
+		c.Start(tm)
+		c.Now()
+		t := c.NewTimer(time.Second)
+		c.NewTicker(300 * ms)
+		c.Advance(time.Second)
+		t.Reset(time.Second)
+		t.Stop()
+		c.Stop()
+
+		var buf bytes.Buffer
+		Expect(c.WriteTrace(&buf)).To(Succeed())
+		type event struct {
+			Name string
+			Ph   string
+			Ts   float64
+			Dur  float64
+			Tid  int
+			Args map[string]any
+		}
+		var tr struct{ TraceEvents []event }
+		Expect(json.Unmarshal(buf.Bytes(), &tr)).To(Succeed())
+		Expect(tr.TraceEvents).To(ContainElements(
+			event{Name: "thread_name", Ph: "M",
+				Args: map[string]any{"name": "clock"}},
+			event{Name: "thread_name", Ph: "M", Tid: 1,
+				Args: map[string]any{"name": "timer-1"}},
+			event{Name: "thread_name", Ph: "M", Tid: 2,
+				Args: map[string]any{"name": "ticker-1"}},
+			event{Name: "now", Ph: "i", Ts: 1000},
+			event{Name: "timer 1s", Ph: "X", Ts: 2000, Dur: 1e6, Tid: 1,
+				Args: map[string]any{"end": "fire"}},
+			event{Name: "ticker 300ms", Ph: "X", Ts: 3000, Dur: 3e5, Tid: 2,
+				Args: map[string]any{"end": "fire"}},
+			event{Name: "timer-1.reset 1s", Ph: "X", Ts: 1004000, Tid: 1,
+				Args: map[string]any{"end": "stop"}},
+		))
+	})
+
+	It("uses the monotonic time on Jumps", func() {
+		tm := time.Date(2021, time.February, 1, 23, 24, 25, 0, time.UTC)
+		c := &Mock{
+			Virtual: true,
+			Default: Script{Now: ms},
+			Jumps:   []Jump{{Call: 1, Offset: -time.Hour}},
+		}
+		c.Start(tm)
+		c.NewTimer(time.Second)
+		c.Now()
+		c.Advance(time.Second)
+		c.Stop()
+
+		var buf bytes.Buffer
+		Expect(c.WriteTrace(&buf)).To(Succeed())
+		type event struct {
+			Name string
+			Ph   string
+			Ts   float64
+			Dur  float64
+			Tid  int
+			Args map[string]any
+		}
+		var tr struct{ TraceEvents []event }
+		Expect(json.Unmarshal(buf.Bytes(), &tr)).To(Succeed())
+		Expect(tr.TraceEvents).To(ContainElements(
+			event{Name: "now", Ph: "i", Ts: 2000},
+			event{Name: "timer 1s", Ph: "X", Ts: 1000, Dur: 1e6, Tid: 1,
+				Args: map[string]any{"end": "fire"}},
+		))
+	})
+
+	It("gives every Sleep its own track", func() {
+		tm := time.Date(2021, time.February, 1, 23, 24, 25, 0, time.UTC)
+		c := &Mock{Virtual: true, Default: Script{Now: ms}}
+		c.Start(tm)
+		var wg sync.WaitGroup
+		for _, d := range []time.Duration{time.Second, 2 * time.Second} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.Sleep(d)
+			}()
+			c.BlockUntil(1)
+		}
+		c.BlockUntil(2)
+		c.Advance(2 * time.Second)
+		wg.Wait()
+		c.Stop()
+
+		var buf bytes.Buffer
+		Expect(c.WriteTrace(&buf)).To(Succeed())
+		type event struct {
+			Name string
+			Ph   string
+			Tid  int
+			Args map[string]any
+		}
+		var tr struct{ TraceEvents []event }
+		Expect(json.Unmarshal(buf.Bytes(), &tr)).To(Succeed())
+		Expect(tr.TraceEvents).To(ContainElements(
+			event{Name: "thread_name", Ph: "M", Tid: 1,
+				Args: map[string]any{"name": "sleep-1"}},
+			event{Name: "thread_name", Ph: "M", Tid: 2,
+				Args: map[string]any{"name": "sleep-2"}},
+			event{Name: "sleep 1s", Ph: "X", Tid: 1,
+				Args: map[string]any{"end": "fire"}},
+			event{Name: "sleep 2s", Ph: "X", Tid: 2,
+				Args: map[string]any{"end": "fire"}},
+		))
+	})
+})